@@ -0,0 +1,247 @@
+// Package value
+/**
+* @Project : GenericGo
+* @File    : value.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/12 10:21
+**/
+
+package value
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/HJH0924/GenericGo/cache"
+	"github.com/HJH0924/GenericGo/list"
+	"github.com/HJH0924/GenericGo/set"
+)
+
+// 本包封装了内存缓存中各类值的操作逻辑，使不同淘汰策略的内存缓存与 Redis 缓存保持相同的语义：
+// 字符串类的值可以直接读写，列表使用 list.LinkedList 存储，集合使用 set.HashSet 存储，
+// 计数器在内部以 int64 或 float64 存储。
+
+// ifaceSize 是一个 any 在内存中占用的字节数，用于估算列表和集合的开销。
+const ifaceSize = int64(16)
+
+// IsContainer 判断 val 是否为列表或集合，这类值不能通过 Get、GetSet 和计数器操作访问。
+func IsContainer(val any) bool {
+	switch val.(type) {
+	case *list.LinkedList[any], *set.HashSet[any]:
+		return true
+	default:
+		return false
+	}
+}
+
+// LPush 将 vals 依次插入到列表的头部，old 为 nil 表示键不存在，此时会创建一个新列表。
+// 如果 old 不是列表，返回 cache.NewErrWrongType。
+func LPush(old any, vals ...any) (*list.LinkedList[any], error) {
+	var l *list.LinkedList[any]
+	switch v := old.(type) {
+	case nil:
+		l = list.NewLinkedList[any]()
+	case *list.LinkedList[any]:
+		l = v
+	default:
+		return nil, cache.NewErrWrongType
+	}
+	for _, val := range vals {
+		l.PushFront(val)
+	}
+	return l, nil
+}
+
+// LPop 移除并返回列表的第一个元素。
+// old 为 nil 或者列表为空时返回 cache.NewErrListEmpty，old 不是列表时返回 cache.NewErrWrongType。
+func LPop(old any) (any, error) {
+	switch v := old.(type) {
+	case nil:
+		return nil, cache.NewErrListEmpty
+	case *list.LinkedList[any]:
+		if v.Len() == 0 {
+			return nil, cache.NewErrListEmpty
+		}
+		return v.Delete(0)
+	default:
+		return nil, cache.NewErrWrongType
+	}
+}
+
+// SAdd 将 members 添加到集合中，old 为 nil 表示键不存在，此时会创建一个新集合。
+// 返回集合以及实际添加的成员（重复的成员只会出现一次），如果 old 不是集合，返回 cache.NewErrWrongType。
+func SAdd(old any, members ...any) (*set.HashSet[any], []any, error) {
+	var s *set.HashSet[any]
+	switch v := old.(type) {
+	case nil:
+		s = set.NewHashSetWithCap[any](len(members))
+	case *set.HashSet[any]:
+		s = v
+	default:
+		return nil, nil, cache.NewErrWrongType
+	}
+	var added []any
+	for _, member := range members {
+		if !s.Contains(member) {
+			s.Add(member)
+			added = append(added, member)
+		}
+	}
+	return s, added, nil
+}
+
+// SRem 从集合中移除 members，返回实际移除的成员数量。
+// old 为 nil 时返回 0，old 不是集合时返回 cache.NewErrWrongType。
+func SRem(old any, members ...any) (int64, error) {
+	switch v := old.(type) {
+	case nil:
+		return 0, nil
+	case *set.HashSet[any]:
+		var cnt int64
+		for _, member := range members {
+			if v.Contains(member) {
+				v.Remove(member)
+				cnt++
+			}
+		}
+		return cnt, nil
+	default:
+		return 0, cache.NewErrWrongType
+	}
+}
+
+// IsEmptyContainer 判断 val 是否为空列表或空集合。
+// 与 Redis 一致，列表或集合中的元素被全部移除后，对应的键也应该被删除。
+func IsEmptyContainer(val any) bool {
+	switch v := val.(type) {
+	case *list.LinkedList[any]:
+		return v.Len() == 0
+	case *set.HashSet[any]:
+		return v.Size() == 0
+	default:
+		return false
+	}
+}
+
+// IncrBy 在 old 的基础上增加 delta 并返回新值，old 为 nil 表示键不存在，此时视为 0。
+// 如果 old 不能被解析为整数或者计算结果溢出，返回 cache.NewErrWrongType。
+func IncrBy(old any, delta int64) (int64, error) {
+	cur, ok := toInt64(old)
+	if !ok {
+		return 0, cache.NewErrWrongType
+	}
+	res := cur + delta
+	// 同号相加结果却变号，说明发生了溢出
+	if (cur > 0 && delta > 0 && res < 0) || (cur < 0 && delta < 0 && res >= 0) {
+		return 0, cache.NewErrWrongType
+	}
+	return res, nil
+}
+
+// IncrByFloat 在 old 的基础上增加 delta 并返回新值，old 为 nil 表示键不存在，此时视为 0。
+// 如果 old 不能被解析为浮点数或者计算结果不是有限数，返回 cache.NewErrWrongType。
+func IncrByFloat(old any, delta float64) (float64, error) {
+	cur, ok := toFloat64(old)
+	if !ok {
+		return 0, cache.NewErrWrongType
+	}
+	res := cur + delta
+	if math.IsNaN(res) || math.IsInf(res, 0) {
+		return 0, cache.NewErrWrongType
+	}
+	return res, nil
+}
+
+// ExpireAt 根据过期时长计算过期时间，expiration 小于等于0时返回零值，表示永不过期。
+func ExpireAt(expiration time.Duration) time.Time {
+	if expiration <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(expiration)
+}
+
+// Cost 估算一个键值对占用的字节数。
+// 字符串和字节切片按长度计算，列表和集合按元素个数计算，其他类型按其类型大小计算。
+func Cost(key string, val any) int64 {
+	res := int64(len(key))
+	switch v := val.(type) {
+	case nil:
+	case string:
+		res += int64(len(v))
+	case []byte:
+		res += int64(len(v))
+	case *list.LinkedList[any]:
+		res += int64(v.Len()) * ifaceSize
+	case *set.HashSet[any]:
+		res += int64(v.Size()) * ifaceSize
+	default:
+		res += int64(reflect.TypeOf(v).Size())
+	}
+	return res
+}
+
+// toInt64 将 val 转换为 int64，val 为 nil 时视为 0。
+func toInt64(val any) (int64, bool) {
+	switch v := val.(type) {
+	case nil:
+		return 0, true
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint:
+		return int64(v), v <= math.MaxInt64
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint64:
+		return int64(v), v <= math.MaxInt64
+	case float32:
+		return toInt64(float64(v))
+	case float64:
+		// 与 Redis 一致，只有整数值的浮点数才能作为整数计数器
+		if v != math.Trunc(v) || v >= math.MaxInt64 || v < math.MinInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	case string:
+		res, err := strconv.ParseInt(v, 10, 64)
+		return res, err == nil
+	case []byte:
+		res, err := strconv.ParseInt(string(v), 10, 64)
+		return res, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// toFloat64 将 val 转换为 float64，val 为 nil 时视为 0。
+func toFloat64(val any) (float64, bool) {
+	switch v := val.(type) {
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		res, err := strconv.ParseFloat(v, 64)
+		return res, err == nil
+	case []byte:
+		res, err := strconv.ParseFloat(string(v), 64)
+		return res, err == nil
+	default:
+		res, ok := toInt64(v)
+		return float64(res), ok
+	}
+}
@@ -11,76 +11,389 @@ package lru
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"time"
 
 	"github.com/HJH0924/GenericGo/cache"
+	"github.com/HJH0924/GenericGo/cache/memory/internal/value"
+	"github.com/HJH0924/GenericGo/list"
+	"github.com/HJH0924/GenericGo/option"
 )
 
 var (
 	_ cache.Cache = (*Cache)(nil)
 )
 
+var (
+	NewErrEntryTooLarge = errors.New("键值对的开销超过了缓存的开销上限")
+)
+
 // Cache 是 cache.Cache 接口的实现，用于操作 LRU 缓存。
 // LRU - Least Recently Used
+// 当键的数量超过 capacity，或者所有键值对的开销之和超过 maxCost 时，淘汰最久未被访问的键值对。
+// 过期的键值对采用惰性删除的策略，在被访问时才会真正从缓存中移除。
+// 单个键值对的开销超过 maxCost 时，写入操作返回 NewErrEntryTooLarge，缓存中原有的内容保持不变。
 type Cache struct {
+	mutex sync.Mutex
+
+	ll      *list.LinkedList[*entry]         // 链表头部为最近访问的节点，尾部为最久未访问的节点
+	entries map[string]*list.Element[*entry] // 键到链表节点的映射
+
+	capacity int   // 最多可以存储的键的数量，小于等于0表示不限制
+	maxCost  int64 // 所有键值对的开销之和的上限，小于等于0表示不限制
+	cost     int64 // 当前所有键值对的开销之和
+
+	costFunc  func(key string, val any) int64 // 计算键值对开销的函数
+	onEvicted func(key string, val any)       // 键值对因容量限制被淘汰时的回调
+}
+
+// entry 是链表节点中存储的键值对
+type entry struct {
+	key      string
+	val      any
+	expireAt time.Time // 过期时间，零值表示永不过期
+	cost     int64
 }
 
+// isExpired 判断键值对在 now 时刻是否已经过期
+func (Self *entry) isExpired(now time.Time) bool {
+	return !Self.expireAt.IsZero() && !now.Before(Self.expireAt)
+}
+
+// Set 设置缓存中的键值对，并可设置过期时间，过期时间小于等于0表示永不过期。
+// 无论键原来是什么类型的值，都会被覆盖。
+// 如果键值对的开销超过了 WithMaxCost 设置的上限，返回 NewErrEntryTooLarge，缓存不会被修改。
 func (Self *Cache) Set(ctx context.Context, key string, val any, expiration time.Duration) error {
-	//TODO implement me
-	panic("implement me")
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	return Self.store(key, val, value.ExpireAt(expiration))
 }
 
+// SetNX (Set if Not eXists) 设置一个键值对，如果键已存在，则返回false，但不会有error，键不存在则可以成功设置，此时返回true
 func (Self *Cache) SetNX(ctx context.Context, key string, val any, expiration time.Duration) (bool, error) {
-	//TODO implement me
-	panic("implement me")
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	if _, ok := Self.getElement(key); ok {
+		return false, nil
+	}
+	if err := Self.store(key, val, value.ExpireAt(expiration)); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
+// Get 获取缓存中的值。
+// 如果键不存在，返回 cache.NewErrKeyNotExist；如果键对应的是列表或集合，返回 cache.NewErrWrongType。
 func (Self *Cache) Get(ctx context.Context, key string) (any, error) {
-	//TODO implement me
-	panic("implement me")
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	elem, ok := Self.getElement(key)
+	if !ok {
+		return nil, cache.NewErrKeyNotExist
+	}
+	ent := elem.Value()
+	if value.IsContainer(ent.val) {
+		return nil, cache.NewErrWrongType
+	}
+	// elem 一定属于 Self.ll，不会返回错误
+	_ = Self.ll.MoveToFront(elem)
+	return ent.val, nil
 }
 
+// GetSet 设置缓存中的键值对，并返回旧值，与 Redis 一致，原有的过期时间会被清除。
+// 如果键不存在，依然会设置新值，但返回 cache.NewErrKeyNotExist。
 func (Self *Cache) GetSet(ctx context.Context, key string, val any) (any, error) {
-	//TODO implement me
-	panic("implement me")
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	elem, ok := Self.getElement(key)
+	if !ok {
+		if err := Self.store(key, val, time.Time{}); err != nil {
+			return nil, err
+		}
+		return nil, cache.NewErrKeyNotExist
+	}
+	old := elem.Value().val
+	if value.IsContainer(old) {
+		return nil, cache.NewErrWrongType
+	}
+	if err := Self.store(key, val, time.Time{}); err != nil {
+		return nil, err
+	}
+	return old, nil
 }
 
+// Delete 删除缓存中的一个或多个键，返回实际删除的键的数量。
 func (Self *Cache) Delete(ctx context.Context, keys ...string) (int64, error) {
-	//TODO implement me
-	panic("implement me")
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	var cnt int64
+	for _, key := range keys {
+		if elem, ok := Self.getElement(key); ok {
+			Self.removeElement(elem)
+			cnt++
+		}
+	}
+	return cnt, nil
 }
 
+// LPush 将一个或多个值插入到列表的头部，返回插入后列表的长度。
 func (Self *Cache) LPush(ctx context.Context, key string, vals ...any) (int64, error) {
-	//TODO implement me
-	panic("implement me")
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	var (
+		old      any
+		deadline time.Time
+	)
+	if elem, ok := Self.getElement(key); ok {
+		ent := elem.Value()
+		old, deadline = ent.val, ent.expireAt
+	}
+	l, err := value.LPush(old, vals...)
+	if err != nil {
+		return 0, err
+	}
+	if err = Self.store(key, l, deadline); err != nil {
+		// 列表是原地修改的，需要撤销本次插入的元素
+		for range vals {
+			_, _ = l.Delete(0)
+		}
+		return 0, err
+	}
+	return int64(l.Len()), nil
 }
 
+// LPop 从列表头部弹出一个元素。
+// 如果列表不存在或者为空，返回 cache.NewErrListEmpty。
 func (Self *Cache) LPop(ctx context.Context, key string) (any, error) {
-	//TODO implement me
-	panic("implement me")
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	elem, ok := Self.getElement(key)
+	if !ok {
+		return nil, cache.NewErrListEmpty
+	}
+	return Self.shrinkContainer(elem, value.LPop)
 }
 
+// SAdd 将一个或多个成员添加到集合中，返回添加成功的成员数量。
 func (Self *Cache) SAdd(ctx context.Context, key string, members ...any) (int64, error) {
-	//TODO implement me
-	panic("implement me")
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	var (
+		old      any
+		deadline time.Time
+	)
+	if elem, ok := Self.getElement(key); ok {
+		ent := elem.Value()
+		old, deadline = ent.val, ent.expireAt
+	}
+	s, added, err := value.SAdd(old, members...)
+	if err != nil {
+		return 0, err
+	}
+	if err = Self.store(key, s, deadline); err != nil {
+		// 集合是原地修改的，需要撤销本次添加的成员
+		s.RemoveKeys(added)
+		return 0, err
+	}
+	return int64(len(added)), nil
 }
 
+// SRem 从集合中移除一个或多个成员，返回实际删除的成员数量。
 func (Self *Cache) SRem(ctx context.Context, key string, members ...any) (int64, error) {
-	//TODO implement me
-	panic("implement me")
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	elem, ok := Self.getElement(key)
+	if !ok {
+		return 0, nil
+	}
+	res, err := Self.shrinkContainer(elem, func(old any) (any, error) {
+		return value.SRem(old, members...)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return res.(int64), nil
 }
 
-func (Self *Cache) IncrBy(ctx context.Context, key string, value int64) (int64, error) {
-	//TODO implement me
-	panic("implement me")
+// IncrBy 增加键对应的整数值，键不存在时视为0，返回增加后的新值。
+func (Self *Cache) IncrBy(ctx context.Context, key string, delta int64) (int64, error) {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	return Self.incrBy(key, delta)
 }
 
+// DecrBy 减少键对应的整数值，键不存在时视为0，返回减少后的新值。
 func (Self *Cache) DecrBy(ctx context.Context, key string, decrement int64) (int64, error) {
-	//TODO implement me
-	panic("implement me")
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	return Self.incrBy(key, -decrement)
+}
+
+// IncrByFloat 增加键对应的浮点数值，键不存在时视为0，返回增加后的新值。
+func (Self *Cache) IncrByFloat(ctx context.Context, key string, delta float64) (float64, error) {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	var (
+		old      any
+		deadline time.Time
+	)
+	if elem, ok := Self.getElement(key); ok {
+		ent := elem.Value()
+		old, deadline = ent.val, ent.expireAt
+	}
+	res, err := value.IncrByFloat(old, delta)
+	if err != nil {
+		return 0, err
+	}
+	if err = Self.store(key, res, deadline); err != nil {
+		return 0, err
+	}
+	return res, nil
+}
+
+// Len 返回缓存中键的数量，其中可能包含已过期但尚未被惰性删除的键。
+func (Self *Cache) Len() int {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	return Self.ll.Len()
+}
+
+// incrBy 是 IncrBy 和 DecrBy 的公共实现，调用方需要持有锁。
+func (Self *Cache) incrBy(key string, delta int64) (int64, error) {
+	var (
+		old      any
+		deadline time.Time
+	)
+	if elem, ok := Self.getElement(key); ok {
+		ent := elem.Value()
+		old, deadline = ent.val, ent.expireAt
+	}
+	res, err := value.IncrBy(old, delta)
+	if err != nil {
+		return 0, err
+	}
+	if err = Self.store(key, res, deadline); err != nil {
+		return 0, err
+	}
+	return res, nil
+}
+
+// shrinkContainer 对列表或集合执行一个会减少元素的操作 op。
+// 与 Redis 一致，操作后如果列表或集合为空，则删除对应的键。
+func (Self *Cache) shrinkContainer(elem *list.Element[*entry], op func(old any) (any, error)) (any, error) {
+	ent := elem.Value()
+	res, err := op(ent.val)
+	if err != nil {
+		return nil, err
+	}
+	if value.IsEmptyContainer(ent.val) {
+		Self.removeElement(elem)
+		return res, nil
+	}
+	if err = Self.store(ent.key, ent.val, ent.expireAt); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// getElement 返回 key 对应的未过期的链表节点，已过期的节点会在这里被删除。
+// 该方法不会改变节点在链表中的位置。
+func (Self *Cache) getElement(key string) (*list.Element[*entry], bool) {
+	elem, ok := Self.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if elem.Value().isExpired(time.Now()) {
+		Self.removeElement(elem)
+		return nil, false
+	}
+	return elem, true
+}
+
+// store 写入键值对，并将其移动到链表头部，随后按需淘汰最久未访问的键值对。
+// 如果键值对的开销超过了 maxCost，写入之后它自身也会被淘汰，此时不写入并返回 NewErrEntryTooLarge。
+func (Self *Cache) store(key string, val any, deadline time.Time) error {
+	cost := Self.costFunc(key, val)
+	if Self.maxCost > 0 && cost > Self.maxCost {
+		return NewErrEntryTooLarge
+	}
+	if elem, ok := Self.entries[key]; ok {
+		ent := elem.Value()
+		Self.cost += cost - ent.cost
+		ent.val, ent.expireAt, ent.cost = val, deadline, cost
+		_ = Self.ll.MoveToFront(elem)
+	} else {
+		Self.entries[key] = Self.ll.PushFront(&entry{
+			key:      key,
+			val:      val,
+			expireAt: deadline,
+			cost:     cost,
+		})
+		Self.cost += cost
+	}
+	Self.evict()
+	return nil
+}
+
+// evict 从链表尾部开始淘汰键值对，直到键的数量和开销都不超过上限。
+func (Self *Cache) evict() {
+	for Self.ll.Len() > 0 && Self.isOverflow() {
+		elem := Self.ll.Back()
+		Self.removeElement(elem)
+		if Self.onEvicted != nil {
+			ent := elem.Value()
+			Self.onEvicted(ent.key, ent.val)
+		}
+	}
+}
+
+// isOverflow 判断缓存是否超过了键的数量上限或开销上限
+func (Self *Cache) isOverflow() bool {
+	return (Self.capacity > 0 && Self.ll.Len() > Self.capacity) ||
+		(Self.maxCost > 0 && Self.cost > Self.maxCost)
+}
+
+// removeElement 从链表和映射中删除节点
+func (Self *Cache) removeElement(elem *list.Element[*entry]) {
+	ent, _ := Self.ll.Remove(elem)
+	delete(Self.entries, ent.key)
+	Self.cost -= ent.cost
+}
+
+// NewCache 创建一个新的 LRU 缓存。
+// capacity 是最多可以存储的键的数量，小于等于0表示不限制键的数量。
+// 使用 WithMaxCost 可以额外按照键值对的开销（字节数）限制缓存的大小。
+func NewCache(capacity int, opts ...option.Option[Cache]) *Cache {
+	res := &Cache{
+		ll:       list.NewLinkedList[*entry](),
+		entries:  make(map[string]*list.Element[*entry]),
+		capacity: capacity,
+		costFunc: value.Cost,
+	}
+	option.Apply(res, opts...)
+	return res
+}
+
+// WithMaxCost 设置所有键值对的开销之和的上限，小于等于0表示不限制。
+func WithMaxCost(maxCost int64) option.Option[Cache] {
+	return func(c *Cache) {
+		c.maxCost = maxCost
+	}
+}
+
+// WithCostFunc 设置计算键值对开销的函数，默认按照键和值的近似字节数计算。
+// 每次写入键值对时都会调用该函数，所以它应该足够轻量。
+func WithCostFunc(costFunc func(key string, val any) int64) option.Option[Cache] {
+	return func(c *Cache) {
+		c.costFunc = costFunc
+	}
 }
 
-func (Self *Cache) IncrByFloat(ctx context.Context, key string, value float64) (float64, error) {
-	//TODO implement me
-	panic("implement me")
+// WithOnEvicted 设置键值对因容量限制被淘汰时的回调。
+// 回调在持有缓存的锁时执行，不能在回调中再操作该缓存。
+func WithOnEvicted(onEvicted func(key string, val any)) option.Option[Cache] {
+	return func(c *Cache) {
+		c.onEvicted = onEvicted
+	}
 }
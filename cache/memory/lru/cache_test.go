@@ -0,0 +1,341 @@
+// Package lru
+/**
+* @Project : GenericGo
+* @File    : cache_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/12 15:06
+**/
+
+package lru
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/HJH0924/GenericGo/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_SetGet(t *testing.T) {
+	tests := []struct {
+		name    string
+		before  func(t *testing.T, c *Cache)
+		key     string
+		wantVal any
+		wantErr error
+	}{
+		{
+			name: "get existing key",
+			before: func(t *testing.T, c *Cache) {
+				require.NoError(t, c.Set(context.Background(), "name", "Tvux", time.Minute))
+			},
+			key:     "name",
+			wantVal: "Tvux",
+		},
+		{
+			name:    "get not exist key",
+			before:  func(t *testing.T, c *Cache) {},
+			key:     "name",
+			wantErr: cache.NewErrKeyNotExist,
+		},
+		{
+			name: "get expired key",
+			before: func(t *testing.T, c *Cache) {
+				require.NoError(t, c.Set(context.Background(), "name", "Tvux", time.Millisecond))
+				time.Sleep(5 * time.Millisecond)
+			},
+			key:     "name",
+			wantErr: cache.NewErrKeyNotExist,
+		},
+		{
+			name: "set overrides list",
+			before: func(t *testing.T, c *Cache) {
+				_, err := c.LPush(context.Background(), "name", "a")
+				require.NoError(t, err)
+				require.NoError(t, c.Set(context.Background(), "name", "Tvux", 0))
+			},
+			key:     "name",
+			wantVal: "Tvux",
+		},
+		{
+			name: "get list",
+			before: func(t *testing.T, c *Cache) {
+				_, err := c.LPush(context.Background(), "name", "a")
+				require.NoError(t, err)
+			},
+			key:     "name",
+			wantErr: cache.NewErrWrongType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCache(10)
+			tt.before(t, c)
+			val, err := c.Get(context.Background(), tt.key)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantVal, val)
+		})
+	}
+}
+
+func TestCache_SetNX(t *testing.T) {
+	ctx := context.Background()
+	c := NewCache(10)
+
+	ok, err := c.SetNX(ctx, "name", "Tvux", time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = c.SetNX(ctx, "name", "other", 0)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// 过期之后可以重新设置
+	time.Sleep(5 * time.Millisecond)
+	ok, err = c.SetNX(ctx, "name", "other", 0)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	val, err := c.Get(ctx, "name")
+	require.NoError(t, err)
+	assert.Equal(t, "other", val)
+}
+
+func TestCache_GetSet(t *testing.T) {
+	ctx := context.Background()
+	c := NewCache(10)
+
+	// 键不存在时依然会设置新值
+	val, err := c.GetSet(ctx, "name", "Tvux")
+	assert.Equal(t, cache.NewErrKeyNotExist, err)
+	assert.Nil(t, val)
+
+	val, err = c.GetSet(ctx, "name", "other")
+	require.NoError(t, err)
+	assert.Equal(t, "Tvux", val)
+
+	val, err = c.Get(ctx, "name")
+	require.NoError(t, err)
+	assert.Equal(t, "other", val)
+
+	_, err = c.SAdd(ctx, "set", 1)
+	require.NoError(t, err)
+	_, err = c.GetSet(ctx, "set", "other")
+	assert.Equal(t, cache.NewErrWrongType, err)
+}
+
+func TestCache_Delete(t *testing.T) {
+	ctx := context.Background()
+	c := NewCache(10)
+	require.NoError(t, c.Set(ctx, "k1", "v1", 0))
+	require.NoError(t, c.Set(ctx, "k2", "v2", 0))
+	require.NoError(t, c.Set(ctx, "k3", "v3", time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+
+	cnt, err := c.Delete(ctx, "k1", "k2", "k3", "k4")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), cnt)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestCache_LPushLPop(t *testing.T) {
+	ctx := context.Background()
+	c := NewCache(10)
+
+	_, err := c.LPop(ctx, "list")
+	assert.Equal(t, cache.NewErrListEmpty, err)
+
+	length, err := c.LPush(ctx, "list", 1, 2, 3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), length)
+
+	for _, want := range []any{3, 2, 1} {
+		val, err := c.LPop(ctx, "list")
+		require.NoError(t, err)
+		assert.Equal(t, want, val)
+	}
+
+	// 列表为空时键被删除
+	_, err = c.LPop(ctx, "list")
+	assert.Equal(t, cache.NewErrListEmpty, err)
+	assert.Equal(t, 0, c.Len())
+
+	require.NoError(t, c.Set(ctx, "name", "Tvux", 0))
+	_, err = c.LPush(ctx, "name", 1)
+	assert.Equal(t, cache.NewErrWrongType, err)
+	_, err = c.LPop(ctx, "name")
+	assert.Equal(t, cache.NewErrWrongType, err)
+}
+
+func TestCache_SAddSRem(t *testing.T) {
+	ctx := context.Background()
+	c := NewCache(10)
+
+	cnt, err := c.SRem(ctx, "set", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), cnt)
+
+	cnt, err = c.SAdd(ctx, "set", 1, 2, 2, 3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), cnt)
+
+	cnt, err = c.SAdd(ctx, "set", 3, 4)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), cnt)
+
+	cnt, err = c.SRem(ctx, "set", 1, 5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), cnt)
+
+	cnt, err = c.SRem(ctx, "set", 2, 3, 4)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), cnt)
+	// 集合为空时键被删除
+	assert.Equal(t, 0, c.Len())
+
+	require.NoError(t, c.Set(ctx, "name", "Tvux", 0))
+	_, err = c.SAdd(ctx, "name", 1)
+	assert.Equal(t, cache.NewErrWrongType, err)
+	_, err = c.SRem(ctx, "name", 1)
+	assert.Equal(t, cache.NewErrWrongType, err)
+}
+
+func TestCache_Incr(t *testing.T) {
+	ctx := context.Background()
+	c := NewCache(10)
+
+	res, err := c.IncrBy(ctx, "cnt", 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), res)
+
+	res, err = c.DecrBy(ctx, "cnt", 3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), res)
+
+	require.NoError(t, c.Set(ctx, "str", "5", 0))
+	res, err = c.IncrBy(ctx, "str", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), res)
+
+	resFloat, err := c.IncrByFloat(ctx, "str", 0.5)
+	require.NoError(t, err)
+	assert.Equal(t, 6.5, resFloat)
+
+	// 非整数不能作为整数计数器
+	_, err = c.IncrBy(ctx, "str", 1)
+	assert.Equal(t, cache.NewErrWrongType, err)
+
+	require.NoError(t, c.Set(ctx, "name", "Tvux", 0))
+	_, err = c.IncrByFloat(ctx, "name", 1)
+	assert.Equal(t, cache.NewErrWrongType, err)
+
+	// 计数器操作保留原有的过期时间
+	require.NoError(t, c.Set(ctx, "ttl", 1, time.Millisecond))
+	_, err = c.IncrBy(ctx, "ttl", 1)
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+	_, err = c.Get(ctx, "ttl")
+	assert.Equal(t, cache.NewErrKeyNotExist, err)
+}
+
+func TestCache_Evict(t *testing.T) {
+	ctx := context.Background()
+	var evicted []string
+	c := NewCache(3, WithOnEvicted(func(key string, val any) {
+		evicted = append(evicted, key)
+	}))
+
+	require.NoError(t, c.Set(ctx, "k1", "v1", 0))
+	require.NoError(t, c.Set(ctx, "k2", "v2", 0))
+	require.NoError(t, c.Set(ctx, "k3", "v3", 0))
+
+	// 访问 k1 之后，最久未访问的是 k2
+	_, err := c.Get(ctx, "k1")
+	require.NoError(t, err)
+
+	require.NoError(t, c.Set(ctx, "k4", "v4", 0))
+	assert.Equal(t, []string{"k2"}, evicted)
+	assert.Equal(t, 3, c.Len())
+
+	_, err = c.Get(ctx, "k2")
+	assert.Equal(t, cache.NewErrKeyNotExist, err)
+	for _, key := range []string{"k1", "k3", "k4"} {
+		_, err = c.Get(ctx, key)
+		assert.NoError(t, err)
+	}
+}
+
+func TestCache_EvictByCost(t *testing.T) {
+	ctx := context.Background()
+	c := NewCache(0, WithMaxCost(10), WithCostFunc(func(key string, val any) int64 {
+		return int64(len(val.(string)))
+	}))
+
+	require.NoError(t, c.Set(ctx, "k1", "aaaa", 0))
+	require.NoError(t, c.Set(ctx, "k2", "bbbb", 0))
+	assert.Equal(t, 2, c.Len())
+
+	// 总开销 12 超过上限 10，淘汰 k1
+	require.NoError(t, c.Set(ctx, "k3", "cccc", 0))
+	assert.Equal(t, 2, c.Len())
+	_, err := c.Get(ctx, "k1")
+	assert.Equal(t, cache.NewErrKeyNotExist, err)
+
+	// 更新值时重新计算开销
+	require.NoError(t, c.Set(ctx, "k3", "c", 0))
+	require.NoError(t, c.Set(ctx, "k4", "ddddd", 0))
+	assert.Equal(t, 3, c.Len())
+}
+
+func TestCache_EntryTooLarge(t *testing.T) {
+	ctx := context.Background()
+	c := NewCache(0, WithMaxCost(10), WithCostFunc(func(key string, val any) int64 {
+		return int64(len(val.(string)))
+	}))
+
+	err := c.Set(ctx, "k1", "aaaaaaaaaaa", 0)
+	assert.Equal(t, NewErrEntryTooLarge, err)
+	_, err = c.Get(ctx, "k1")
+	assert.Equal(t, cache.NewErrKeyNotExist, err)
+
+	// 写入失败时保留原有的值
+	require.NoError(t, c.Set(ctx, "k2", "bbbb", 0))
+	ok, err := c.SetNX(ctx, "k3", "ccccccccccc", 0)
+	assert.Equal(t, NewErrEntryTooLarge, err)
+	assert.False(t, ok)
+	err = c.Set(ctx, "k2", "bbbbbbbbbbb", 0)
+	assert.Equal(t, NewErrEntryTooLarge, err)
+	val, err := c.Get(ctx, "k2")
+	require.NoError(t, err)
+	assert.Equal(t, "bbbb", val)
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestCache_ContainerTooLarge(t *testing.T) {
+	ctx := context.Background()
+	// 默认的开销函数中，键 "k" 占 1，每个元素占 16
+	c := NewCache(0, WithMaxCost(40))
+
+	length, err := c.LPush(ctx, "list", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), length)
+	// 插入失败时撤销对列表的修改
+	_, err = c.LPush(ctx, "list", 2, 3)
+	assert.Equal(t, NewErrEntryTooLarge, err)
+	val, err := c.LPop(ctx, "list")
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+
+	cnt, err := c.SAdd(ctx, "set", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), cnt)
+	_, err = c.SAdd(ctx, "set", 1, 2, 3)
+	assert.Equal(t, NewErrEntryTooLarge, err)
+	cnt, err = c.SRem(ctx, "set", 1, 2, 3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), cnt)
+}
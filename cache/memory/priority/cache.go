@@ -9,4 +9,379 @@
 
 package priority
 
-// TODO 需要实现红黑树
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/HJH0924/GenericGo/cache"
+	"github.com/HJH0924/GenericGo/cache/memory/internal/value"
+	"github.com/HJH0924/GenericGo/option"
+	"github.com/HJH0924/GenericGo/tree"
+)
+
+var (
+	_ cache.Cache = (*Cache)(nil)
+)
+
+// Prioritizer 可以由缓存的值实现，用于在默认情况下决定该值的优先级。
+type Prioritizer interface {
+	// Priority 返回值的优先级，优先级越低越先被淘汰。
+	Priority() int
+}
+
+// Cache 是 cache.Cache 接口的实现，基于优先级淘汰的内存缓存。
+// 每个键值对都有一个优先级，当键的数量超过 capacity 时，优先淘汰优先级最低的键值对，
+// 优先级相同时，淘汰最早写入的键值对。
+// 所有键值对按照优先级存储在红黑树中，因此淘汰的时间复杂度为 O(log n)。
+// 过期的键值对采用惰性删除的策略，在被访问时才会真正从缓存中移除。
+type Cache struct {
+	mutex sync.Mutex
+
+	entries map[string]*entry                   // 键到键值对的映射
+	tree    *tree.RBTree[priorityKey, struct{}] // 按照优先级排序的键值对，用于淘汰
+	seq     uint64                              // 单调递增的写入序号，用于区分优先级相同的键值对
+
+	capacity     int                           // 最多可以存储的键的数量，小于等于0表示不限制
+	priorityFunc func(key string, val any) int // 计算键值对优先级的函数
+	onEvicted    func(key string, val any)     // 键值对因容量限制被淘汰时的回调
+}
+
+// priorityKey 是红黑树中的键，先按优先级排序，再按写入序号排序
+type priorityKey struct {
+	priority int
+	seq      uint64
+	key      string
+}
+
+// comparePriorityKey 是 priorityKey 的比较器
+func comparePriorityKey(left, right priorityKey) int {
+	switch {
+	case left.priority < right.priority:
+		return -1
+	case left.priority > right.priority:
+		return 1
+	case left.seq < right.seq:
+		return -1
+	case left.seq > right.seq:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// entry 是缓存中存储的键值对
+type entry struct {
+	val      any
+	expireAt time.Time // 过期时间，零值表示永不过期
+	pk       priorityKey
+}
+
+// isExpired 判断键值对在 now 时刻是否已经过期
+func (Self *entry) isExpired(now time.Time) bool {
+	return !Self.expireAt.IsZero() && !now.Before(Self.expireAt)
+}
+
+// Set 设置缓存中的键值对，并可设置过期时间，过期时间小于等于0表示永不过期。
+// 键值对的优先级由 priorityFunc 计算。
+func (Self *Cache) Set(ctx context.Context, key string, val any, expiration time.Duration) error {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	Self.store(key, val, Self.priorityFunc(key, val), value.ExpireAt(expiration))
+	return nil
+}
+
+// SetWithPriority 设置缓存中的键值对，并显式指定其优先级，过期时间小于等于0表示永不过期。
+func (Self *Cache) SetWithPriority(ctx context.Context, key string, val any, priority int, expiration time.Duration) error {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	Self.store(key, val, priority, value.ExpireAt(expiration))
+	return nil
+}
+
+// SetNX (Set if Not eXists) 设置一个键值对，如果键已存在，则返回false，但不会有error，键不存在则可以成功设置，此时返回true
+func (Self *Cache) SetNX(ctx context.Context, key string, val any, expiration time.Duration) (bool, error) {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	if _, ok := Self.getEntry(key); ok {
+		return false, nil
+	}
+	Self.store(key, val, Self.priorityFunc(key, val), value.ExpireAt(expiration))
+	return true, nil
+}
+
+// Get 获取缓存中的值。
+// 如果键不存在，返回 cache.NewErrKeyNotExist；如果键对应的是列表或集合，返回 cache.NewErrWrongType。
+func (Self *Cache) Get(ctx context.Context, key string) (any, error) {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	ent, ok := Self.getEntry(key)
+	if !ok {
+		return nil, cache.NewErrKeyNotExist
+	}
+	if value.IsContainer(ent.val) {
+		return nil, cache.NewErrWrongType
+	}
+	return ent.val, nil
+}
+
+// GetSet 设置缓存中的键值对，并返回旧值，与 Redis 一致，原有的过期时间会被清除。
+// 如果键不存在，依然会设置新值，但返回 cache.NewErrKeyNotExist。
+func (Self *Cache) GetSet(ctx context.Context, key string, val any) (any, error) {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	ent, ok := Self.getEntry(key)
+	if !ok {
+		Self.store(key, val, Self.priorityFunc(key, val), time.Time{})
+		return nil, cache.NewErrKeyNotExist
+	}
+	old := ent.val
+	if value.IsContainer(old) {
+		return nil, cache.NewErrWrongType
+	}
+	Self.store(key, val, Self.priorityFunc(key, val), time.Time{})
+	return old, nil
+}
+
+// Delete 删除缓存中的一个或多个键，返回实际删除的键的数量。
+func (Self *Cache) Delete(ctx context.Context, keys ...string) (int64, error) {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	var cnt int64
+	for _, key := range keys {
+		if ent, ok := Self.getEntry(key); ok {
+			Self.remove(ent)
+			cnt++
+		}
+	}
+	return cnt, nil
+}
+
+// LPush 将一个或多个值插入到列表的头部，返回插入后列表的长度。
+// 新创建的列表的优先级由 priorityFunc 计算，已存在的列表保持原有的优先级。
+func (Self *Cache) LPush(ctx context.Context, key string, vals ...any) (int64, error) {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	ent, ok := Self.getEntry(key)
+	if ok {
+		l, err := value.LPush(ent.val, vals...)
+		if err != nil {
+			return 0, err
+		}
+		return int64(l.Len()), nil
+	}
+	l, _ := value.LPush(nil, vals...)
+	length := int64(l.Len())
+	Self.store(key, l, Self.priorityFunc(key, l), time.Time{})
+	return length, nil
+}
+
+// LPop 从列表头部弹出一个元素。
+// 如果列表不存在或者为空，返回 cache.NewErrListEmpty。
+func (Self *Cache) LPop(ctx context.Context, key string) (any, error) {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	ent, ok := Self.getEntry(key)
+	if !ok {
+		return nil, cache.NewErrListEmpty
+	}
+	res, err := value.LPop(ent.val)
+	if err != nil {
+		return nil, err
+	}
+	Self.removeIfEmpty(ent)
+	return res, nil
+}
+
+// SAdd 将一个或多个成员添加到集合中，返回添加成功的成员数量。
+// 新创建的集合的优先级由 priorityFunc 计算，已存在的集合保持原有的优先级。
+func (Self *Cache) SAdd(ctx context.Context, key string, members ...any) (int64, error) {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	ent, ok := Self.getEntry(key)
+	if ok {
+		_, added, err := value.SAdd(ent.val, members...)
+		return int64(len(added)), err
+	}
+	s, added, _ := value.SAdd(nil, members...)
+	Self.store(key, s, Self.priorityFunc(key, s), time.Time{})
+	return int64(len(added)), nil
+}
+
+// SRem 从集合中移除一个或多个成员，返回实际删除的成员数量。
+func (Self *Cache) SRem(ctx context.Context, key string, members ...any) (int64, error) {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	ent, ok := Self.getEntry(key)
+	if !ok {
+		return 0, nil
+	}
+	cnt, err := value.SRem(ent.val, members...)
+	if err != nil {
+		return 0, err
+	}
+	Self.removeIfEmpty(ent)
+	return cnt, nil
+}
+
+// IncrBy 增加键对应的整数值，键不存在时视为0，返回增加后的新值。
+func (Self *Cache) IncrBy(ctx context.Context, key string, delta int64) (int64, error) {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	return Self.incrBy(key, delta)
+}
+
+// DecrBy 减少键对应的整数值，键不存在时视为0，返回减少后的新值。
+func (Self *Cache) DecrBy(ctx context.Context, key string, decrement int64) (int64, error) {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	return Self.incrBy(key, -decrement)
+}
+
+// IncrByFloat 增加键对应的浮点数值，键不存在时视为0，返回增加后的新值。
+func (Self *Cache) IncrByFloat(ctx context.Context, key string, delta float64) (float64, error) {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	ent, ok := Self.getEntry(key)
+	if !ok {
+		res, err := value.IncrByFloat(nil, delta)
+		if err != nil {
+			return 0, err
+		}
+		Self.store(key, res, Self.priorityFunc(key, res), time.Time{})
+		return res, nil
+	}
+	res, err := value.IncrByFloat(ent.val, delta)
+	if err != nil {
+		return 0, err
+	}
+	ent.val = res
+	return res, nil
+}
+
+// Len 返回缓存中键的数量，其中可能包含已过期但尚未被惰性删除的键。
+func (Self *Cache) Len() int {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	return len(Self.entries)
+}
+
+// incrBy 是 IncrBy 和 DecrBy 的公共实现，调用方需要持有锁。
+// 计数器的值在原地更新，保持原有的优先级和过期时间。
+func (Self *Cache) incrBy(key string, delta int64) (int64, error) {
+	ent, ok := Self.getEntry(key)
+	if !ok {
+		res, err := value.IncrBy(nil, delta)
+		if err != nil {
+			return 0, err
+		}
+		Self.store(key, res, Self.priorityFunc(key, res), time.Time{})
+		return res, nil
+	}
+	res, err := value.IncrBy(ent.val, delta)
+	if err != nil {
+		return 0, err
+	}
+	ent.val = res
+	return res, nil
+}
+
+// getEntry 返回 key 对应的未过期的键值对，已过期的键值对会在这里被删除。
+func (Self *Cache) getEntry(key string) (*entry, bool) {
+	ent, ok := Self.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if ent.isExpired(time.Now()) {
+		Self.remove(ent)
+		return nil, false
+	}
+	return ent, true
+}
+
+// store 写入键值对，并按照新的优先级重新排序，随后按需淘汰优先级最低的键值对。
+// 注意：如果新写入的键值对的优先级最低，它可能会被立即淘汰。
+func (Self *Cache) store(key string, val any, priority int, deadline time.Time) {
+	if old, ok := Self.entries[key]; ok {
+		Self.tree.Delete(old.pk)
+	}
+	Self.seq++
+	ent := &entry{
+		val:      val,
+		expireAt: deadline,
+		pk: priorityKey{
+			priority: priority,
+			seq:      Self.seq,
+			key:      key,
+		},
+	}
+	Self.entries[key] = ent
+	Self.tree.Put(ent.pk, struct{}{})
+	Self.evict()
+}
+
+// evict 淘汰优先级最低的键值对，直到键的数量不超过上限。
+func (Self *Cache) evict() {
+	for Self.capacity > 0 && len(Self.entries) > Self.capacity {
+		pk, _, ok := Self.tree.Min()
+		if !ok {
+			return
+		}
+		ent := Self.entries[pk.key]
+		Self.remove(ent)
+		if Self.onEvicted != nil {
+			Self.onEvicted(pk.key, ent.val)
+		}
+	}
+}
+
+// removeIfEmpty 与 Redis 一致，列表或集合中的元素被全部移除后，删除对应的键
+func (Self *Cache) removeIfEmpty(ent *entry) {
+	if value.IsEmptyContainer(ent.val) {
+		Self.remove(ent)
+	}
+}
+
+// remove 从映射和红黑树中删除键值对
+func (Self *Cache) remove(ent *entry) {
+	delete(Self.entries, ent.pk.key)
+	Self.tree.Delete(ent.pk)
+}
+
+// defaultPriority 是默认的优先级计算函数。
+// 如果值实现了 Prioritizer 接口，则使用其优先级，否则优先级为0。
+func defaultPriority(key string, val any) int {
+	if p, ok := val.(Prioritizer); ok {
+		return p.Priority()
+	}
+	return 0
+}
+
+// NewCache 创建一个新的基于优先级淘汰的缓存。
+// capacity 是最多可以存储的键的数量，小于等于0表示不限制键的数量。
+func NewCache(capacity int, opts ...option.Option[Cache]) *Cache {
+	res := &Cache{
+		entries:      make(map[string]*entry),
+		tree:         tree.NewRBTree[priorityKey, struct{}](comparePriorityKey),
+		capacity:     capacity,
+		priorityFunc: defaultPriority,
+	}
+	option.Apply(res, opts...)
+	return res
+}
+
+// WithPriorityFunc 设置计算键值对优先级的函数，优先级越低越先被淘汰。
+// 可以根据值的计算代价等因素给出权重，让计算代价高的键值对常驻缓存。
+func WithPriorityFunc(priorityFunc func(key string, val any) int) option.Option[Cache] {
+	return func(c *Cache) {
+		c.priorityFunc = priorityFunc
+	}
+}
+
+// WithOnEvicted 设置键值对因容量限制被淘汰时的回调。
+// 回调在持有缓存的锁时执行，不能在回调中再操作该缓存。
+func WithOnEvicted(onEvicted func(key string, val any)) option.Option[Cache] {
+	return func(c *Cache) {
+		c.onEvicted = onEvicted
+	}
+}
@@ -0,0 +1,199 @@
+// Package priority
+/**
+* @Project : GenericGo
+* @File    : cache_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/14 16:20
+**/
+
+package priority
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/HJH0924/GenericGo/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type weightedVal struct {
+	weight int
+}
+
+func (Self weightedVal) Priority() int {
+	return Self.weight
+}
+
+func TestCache_SetGet(t *testing.T) {
+	tests := []struct {
+		name    string
+		before  func(t *testing.T, c *Cache)
+		key     string
+		wantVal any
+		wantErr error
+	}{
+		{
+			name: "get existing key",
+			before: func(t *testing.T, c *Cache) {
+				require.NoError(t, c.Set(context.Background(), "name", "Tvux", time.Minute))
+			},
+			key:     "name",
+			wantVal: "Tvux",
+		},
+		{
+			name:    "get not exist key",
+			before:  func(t *testing.T, c *Cache) {},
+			key:     "name",
+			wantErr: cache.NewErrKeyNotExist,
+		},
+		{
+			name: "get expired key",
+			before: func(t *testing.T, c *Cache) {
+				require.NoError(t, c.Set(context.Background(), "name", "Tvux", time.Millisecond))
+				time.Sleep(5 * time.Millisecond)
+			},
+			key:     "name",
+			wantErr: cache.NewErrKeyNotExist,
+		},
+		{
+			name: "get set",
+			before: func(t *testing.T, c *Cache) {
+				_, err := c.SAdd(context.Background(), "name", "a")
+				require.NoError(t, err)
+			},
+			key:     "name",
+			wantErr: cache.NewErrWrongType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCache(10)
+			tt.before(t, c)
+			val, err := c.Get(context.Background(), tt.key)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantVal, val)
+		})
+	}
+}
+
+func TestCache_SetNXGetSetDelete(t *testing.T) {
+	ctx := context.Background()
+	c := NewCache(10)
+
+	ok, err := c.SetNX(ctx, "name", "Tvux", 0)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = c.SetNX(ctx, "name", "other", 0)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	val, err := c.GetSet(ctx, "name", "other")
+	require.NoError(t, err)
+	assert.Equal(t, "Tvux", val)
+	_, err = c.GetSet(ctx, "age", 18)
+	assert.Equal(t, cache.NewErrKeyNotExist, err)
+
+	cnt, err := c.Delete(ctx, "name", "age", "none")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), cnt)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestCache_ListSetCounter(t *testing.T) {
+	ctx := context.Background()
+	c := NewCache(10)
+
+	length, err := c.LPush(ctx, "list", 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), length)
+	length, err = c.LPush(ctx, "list", 3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), length)
+	for _, want := range []any{3, 2, 1} {
+		val, err := c.LPop(ctx, "list")
+		require.NoError(t, err)
+		assert.Equal(t, want, val)
+	}
+	_, err = c.LPop(ctx, "list")
+	assert.Equal(t, cache.NewErrListEmpty, err)
+
+	cnt, err := c.SAdd(ctx, "set", 1, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), cnt)
+	cnt, err = c.SRem(ctx, "set", 1, 2, 3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), cnt)
+
+	res, err := c.IncrBy(ctx, "cnt", 5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), res)
+	res, err = c.DecrBy(ctx, "cnt", 7)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-2), res)
+	resFloat, err := c.IncrByFloat(ctx, "cnt", 0.5)
+	require.NoError(t, err)
+	assert.Equal(t, -1.5, resFloat)
+
+	_, err = c.LPush(ctx, "cnt", 1)
+	assert.Equal(t, cache.NewErrWrongType, err)
+	_, err = c.SAdd(ctx, "cnt", 1)
+	assert.Equal(t, cache.NewErrWrongType, err)
+	_, err = c.IncrBy(ctx, "cnt", 1)
+	assert.Equal(t, cache.NewErrWrongType, err)
+
+	// 只剩下计数器
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestCache_Evict(t *testing.T) {
+	ctx := context.Background()
+	var evicted []string
+	c := NewCache(3, WithOnEvicted(func(key string, val any) {
+		evicted = append(evicted, key)
+	}))
+
+	require.NoError(t, c.SetWithPriority(ctx, "k1", "v1", 10, 0))
+	require.NoError(t, c.Set(ctx, "k2", weightedVal{weight: 1}, 0))
+	require.NoError(t, c.SetWithPriority(ctx, "k3", "v3", 5, 0))
+
+	// 淘汰优先级最低的 k2
+	require.NoError(t, c.SetWithPriority(ctx, "k4", "v4", 5, 0))
+	assert.Equal(t, []string{"k2"}, evicted)
+
+	// 优先级相同时淘汰最早写入的 k3
+	require.NoError(t, c.SetWithPriority(ctx, "k5", "v5", 7, 0))
+	assert.Equal(t, []string{"k2", "k3"}, evicted)
+
+	// 新写入的键值对优先级最低时，它自己会被淘汰
+	require.NoError(t, c.SetWithPriority(ctx, "k6", "v6", 0, 0))
+	assert.Equal(t, []string{"k2", "k3", "k6"}, evicted)
+
+	assert.Equal(t, 3, c.Len())
+	for _, key := range []string{"k1", "k4", "k5"} {
+		_, err := c.Get(ctx, key)
+		assert.NoError(t, err)
+	}
+}
+
+func TestCache_WithPriorityFunc(t *testing.T) {
+	ctx := context.Background()
+	c := NewCache(2, WithPriorityFunc(func(key string, val any) int {
+		return len(val.(string))
+	}))
+
+	require.NoError(t, c.Set(ctx, "k1", "expensive", 0))
+	require.NoError(t, c.Set(ctx, "k2", "cheap", 0))
+	require.NoError(t, c.Set(ctx, "k3", "c", 0))
+	require.NoError(t, c.Set(ctx, "k4", "costly", 0))
+
+	_, err := c.Get(ctx, "k1")
+	assert.NoError(t, err)
+	_, err = c.Get(ctx, "k4")
+	assert.NoError(t, err)
+	_, err = c.Get(ctx, "k2")
+	assert.Equal(t, cache.NewErrKeyNotExist, err)
+}
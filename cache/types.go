@@ -18,6 +18,7 @@ import (
 var (
 	NewErrKeyNotExist = errors.New("key不存在")
 	NewErrListEmpty   = errors.New("列表为空")
+	NewErrWrongType   = errors.New("key对应的值类型不支持该操作")
 )
 
 // Cache 定义了缓存操作的接口
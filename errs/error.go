@@ -29,3 +29,15 @@ func NewErrEmptyQueue() error {
 func NewErrOutOfCapacity() error {
 	return errors.New("capacity exceeded, unable to add more elements")
 }
+
+func NewErrInvalidHandle() error {
+	return errors.New("the handle does not belong to this container or has already been removed")
+}
+
+func NewErrEmptyStack() error {
+	return errors.New("the stack is empty, unable to perform operation")
+}
+
+func NewErrInvalidBinaryData(reason string) error {
+	return fmt.Errorf("invalid binary data: %s", reason)
+}
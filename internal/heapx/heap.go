@@ -0,0 +1,58 @@
+// Package heapx
+/**
+* @Project : GenericGo
+* @File    : heap.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/30 10:05
+**/
+
+package heapx
+
+import genericgo "github.com/HJH0924/GenericGo"
+
+// 本包提供 d 叉堆的基本操作，供 queue.PriorityQueue 和 slice.TopK 共用。
+// 堆保存在下标从0开始的切片中，下标为 i 的节点的子节点为 arity*i+1 到 arity*i+arity，父节点为 (i-1)/arity。
+// compare 返回值大于0表示左边的元素优先级更高，堆顶是优先级最高的元素。
+
+// Up 将下标为 i 的元素上浮，返回元素是否移动过
+func Up[T any](h []T, i int, arity int, compare genericgo.Comparator[T]) bool {
+	moved := false
+	for i > 0 {
+		parent := (i - 1) / arity
+		if compare(h[i], h[parent]) <= 0 {
+			break
+		}
+		h[i], h[parent] = h[parent], h[i]
+		i = parent
+		moved = true
+	}
+	return moved
+}
+
+// Down 将下标为 i 的元素下沉，返回元素是否移动过
+func Down[T any](h []T, i int, arity int, compare genericgo.Comparator[T]) bool {
+	moved, n := false, len(h)
+	for {
+		maxPos := i
+		first := arity*i + 1
+		for child := first; child < first+arity && child < n; child++ {
+			if compare(h[child], h[maxPos]) > 0 {
+				maxPos = child
+			}
+		}
+		if maxPos == i {
+			return moved
+		}
+		h[i], h[maxPos] = h[maxPos], h[i]
+		i = maxPos
+		moved = true
+	}
+}
+
+// Heapify 在 O(n) 的时间内将 h 调整为堆，从最后一个非叶子节点开始依次下沉
+func Heapify[T any](h []T, arity int, compare genericgo.Comparator[T]) {
+	for i := (len(h) - 2) / arity; i >= 0; i-- {
+		Down(h, i, arity, compare)
+	}
+}
@@ -0,0 +1,88 @@
+// Package heapx
+/**
+* @Project : GenericGo
+* @File    : heap_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/30 10:40
+**/
+
+package heapx
+
+import (
+	"cmp"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// isHeap 判断 h 是否满足堆的性质
+func isHeap(h []int, arity int) bool {
+	for i := 1; i < len(h); i++ {
+		if h[i] > h[(i-1)/arity] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestHeapify(t *testing.T) {
+	tests := []struct {
+		name  string
+		vals  []int
+		arity int
+	}{
+		{
+			name:  "Test empty",
+			vals:  []int{},
+			arity: 2,
+		},
+		{
+			name:  "Test single",
+			vals:  []int{1},
+			arity: 2,
+		},
+		{
+			name:  "Test binary heap",
+			vals:  []int{41, 62, 67, 87, 41, 78, 45, 28, 25, 58},
+			arity: 2,
+		},
+		{
+			name:  "Test 4-ary heap",
+			vals:  []int{41, 62, 67, 87, 41, 78, 45, 28, 25, 58},
+			arity: 4,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			Heapify(test.vals, test.arity, cmp.Compare[int])
+			assert.True(t, isHeap(test.vals, test.arity))
+		})
+	}
+}
+
+func TestUpDown(t *testing.T) {
+	r := rand.New(rand.NewSource(2024))
+	for _, arity := range []int{2, 3, 4, 8} {
+		h := make([]int, 0, 200)
+		for i := 0; i < 200; i++ {
+			h = append(h, r.Intn(1000))
+			Up(h, len(h)-1, arity, cmp.Compare[int])
+			assert.True(t, isHeap(h, arity))
+		}
+
+		// 依次弹出堆顶，结果应当从大到小排列
+		prev := h[0]
+		for len(h) > 0 {
+			top := h[0]
+			assert.LessOrEqual(t, top, prev)
+			prev = top
+			h[0] = h[len(h)-1]
+			h = h[:len(h)-1]
+			Down(h, 0, arity, cmp.Compare[int])
+			assert.True(t, isHeap(h, arity))
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Package iterator
+/**
+* @Project : GenericGo
+* @File    : adapter.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/5 10:12
+**/
+
+package iterator
+
+// Map 返回一个惰性的迭代器，依次产出 fn 作用于 it 中每个元素的结果。
+func Map[T any, R any](it Iterator[T], fn func(T) R) Iterator[R] {
+	return FromFunc(func() (R, bool) {
+		if !it.Next() {
+			var zero R
+			return zero, false
+		}
+		return fn(it.Value()), true
+	})
+}
+
+// Filter 返回一个惰性的迭代器，只产出 it 中满足 match 的元素。
+func Filter[T any](it Iterator[T], match func(T) bool) Iterator[T] {
+	return FromFunc(func() (T, bool) {
+		for it.Next() {
+			if val := it.Value(); match(val) {
+				return val, true
+			}
+		}
+		var zero T
+		return zero, false
+	})
+}
+
+// Take 返回一个惰性的迭代器，最多产出 it 中的前 n 个元素。
+// 产出 n 个元素之后不会再推进 it。
+func Take[T any](it Iterator[T], n int) Iterator[T] {
+	return FromFunc(func() (T, bool) {
+		if n <= 0 || !it.Next() {
+			var zero T
+			return zero, false
+		}
+		n--
+		return it.Value(), true
+	})
+}
+
+// Collect 遍历 it 中剩余的所有元素，并返回由这些元素组成的切片，
+// 即使没有元素，也返回一个长度为0的切片。
+func Collect[T any](it Iterator[T]) []T {
+	res := make([]T, 0)
+	for it.Next() {
+		res = append(res, it.Value())
+	}
+	return res
+}
+
+// ForEach 遍历 it 中剩余的所有元素，visit 返回 false 时停止遍历。
+func ForEach[T any](it Iterator[T], visit func(T) bool) {
+	for it.Next() {
+		if !visit(it.Value()) {
+			return
+		}
+	}
+}
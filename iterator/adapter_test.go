@@ -0,0 +1,135 @@
+// Package iterator
+/**
+* @Project : GenericGo
+* @File    : adapter_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/5 11:36
+**/
+
+package iterator
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingIterator 返回一个产出 0 到 n-1 的迭代器，以及已经产出的元素数量
+func countingIterator(n int) (Iterator[int], *int) {
+	produced := 0
+	return FromFunc(func() (int, bool) {
+		if produced >= n {
+			return 0, false
+		}
+		produced++
+		return produced - 1, true
+	}), &produced
+}
+
+func TestMap(t *testing.T) {
+	it, _ := countingIterator(3)
+	res := Collect(Map(it, func(val int) string {
+		return strconv.Itoa(val * 2)
+	}))
+	assert.Equal(t, []string{"0", "2", "4"}, res)
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		n        int
+		match    func(int) bool
+		wantVals []int
+	}{
+		{
+			name: "Match even",
+			n:    6,
+			match: func(val int) bool {
+				return val%2 == 0
+			},
+			wantVals: []int{0, 2, 4},
+		},
+		{
+			name: "Match none",
+			n:    6,
+			match: func(val int) bool {
+				return false
+			},
+			wantVals: []int{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			it, _ := countingIterator(test.n)
+			assert.Equal(t, test.wantVals, Collect(Filter(it, test.match)))
+		})
+	}
+}
+
+func TestTake(t *testing.T) {
+	tests := []struct {
+		name         string
+		n            int
+		take         int
+		wantVals     []int
+		wantProduced int
+	}{
+		{
+			name:         "Take less than length",
+			n:            100,
+			take:         3,
+			wantVals:     []int{0, 1, 2},
+			wantProduced: 3,
+		},
+		{
+			name:         "Take more than length",
+			n:            2,
+			take:         5,
+			wantVals:     []int{0, 1},
+			wantProduced: 2,
+		},
+		{
+			name:         "Take zero",
+			n:            2,
+			take:         0,
+			wantVals:     []int{},
+			wantProduced: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			it, produced := countingIterator(test.n)
+			assert.Equal(t, test.wantVals, Collect(Take(it, test.take)))
+			// Take 不会多推进底层迭代器
+			assert.Equal(t, test.wantProduced, *produced)
+		})
+	}
+}
+
+func TestAdapters_Lazy(t *testing.T) {
+	// 对一个很长的迭代器组合使用适配器，只会推进需要的元素
+	it, produced := countingIterator(1 << 30)
+	evens := Filter(it, func(val int) bool {
+		return val%2 == 0
+	})
+	squares := Map(evens, func(val int) int {
+		return val * val
+	})
+	assert.Equal(t, []int{0, 4, 16}, Collect(Take(squares, 3)))
+	assert.Equal(t, 5, *produced)
+}
+
+func TestForEach(t *testing.T) {
+	it, produced := countingIterator(10)
+	res := make([]int, 0)
+	ForEach(it, func(val int) bool {
+		res = append(res, val)
+		return val < 3
+	})
+	assert.Equal(t, []int{0, 1, 2, 3}, res)
+	assert.Equal(t, 4, *produced)
+}
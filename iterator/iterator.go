@@ -0,0 +1,100 @@
+// Package iterator
+/**
+* @Project : GenericGo
+* @File    : iterator.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/5 09:30
+**/
+
+package iterator
+
+// Iterator 是一个惰性的迭代器，典型的用法是：
+//
+//	for it.Next() {
+//		val := it.Value()
+//	}
+//
+// 迭代器不会复制底层容器的数据，在遍历期间修改底层容器的结果是未定义的。
+type Iterator[T any] interface {
+	// Next 将迭代器移动到下一个元素，如果没有更多的元素，返回 false。
+	// 第一次调用 Next 会移动到第一个元素。
+	Next() bool
+
+	// Value 返回当前元素，只有在 Next 返回 true 之后调用才有意义。
+	Value() T
+}
+
+// Iterable 是可以从前往后遍历的容器
+type Iterable[T any] interface {
+	// Iterator 返回一个从第一个元素开始遍历的迭代器。
+	Iterator() Iterator[T]
+}
+
+// ReverseIterable 是可以从后往前遍历的容器
+type ReverseIterable[T any] interface {
+	Iterable[T]
+
+	// ReverseIterator 返回一个从最后一个元素开始遍历的迭代器。
+	ReverseIterator() Iterator[T]
+}
+
+// funcIterator 使用函数实现 Iterator，next 返回下一个元素，第二个返回值为 false 表示遍历结束
+type funcIterator[T any] struct {
+	next func() (T, bool)
+	cur  T
+	done bool
+}
+
+func (it *funcIterator[T]) Next() bool {
+	if it.done {
+		return false
+	}
+	val, ok := it.next()
+	if !ok {
+		// 遍历结束后不再调用 next，并释放对最后一个元素的引用
+		var zero T
+		it.cur, it.done = zero, true
+		return false
+	}
+	it.cur = val
+	return true
+}
+
+func (it *funcIterator[T]) Value() T {
+	return it.cur
+}
+
+// FromFunc 使用 next 创建一个 Iterator，每次调用 Next 时调用 next 获取下一个元素，
+// next 的第二个返回值为 false 表示遍历结束，之后不会再调用 next。
+func FromFunc[T any](next func() (T, bool)) Iterator[T] {
+	return &funcIterator[T]{
+		next: next,
+	}
+}
+
+// FromSlice 返回一个从前往后遍历 vals 的迭代器，不会复制 vals。
+func FromSlice[T any](vals []T) Iterator[T] {
+	i := 0
+	return FromFunc(func() (T, bool) {
+		if i >= len(vals) {
+			var zero T
+			return zero, false
+		}
+		i++
+		return vals[i-1], true
+	})
+}
+
+// FromSliceReverse 返回一个从后往前遍历 vals 的迭代器，不会复制 vals。
+func FromSliceReverse[T any](vals []T) Iterator[T] {
+	i := len(vals)
+	return FromFunc(func() (T, bool) {
+		if i <= 0 {
+			var zero T
+			return zero, false
+		}
+		i--
+		return vals[i], true
+	})
+}
@@ -0,0 +1,72 @@
+// Package iterator
+/**
+* @Project : GenericGo
+* @File    : iterator_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/5 11:02
+**/
+
+package iterator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromSlice(t *testing.T) {
+	tests := []struct {
+		name        string
+		vals        []int
+		wantVals    []int
+		wantReverse []int
+	}{
+		{
+			name:        "Empty slice",
+			vals:        []int{},
+			wantVals:    []int{},
+			wantReverse: []int{},
+		},
+		{
+			name:        "Nil slice",
+			vals:        nil,
+			wantVals:    []int{},
+			wantReverse: []int{},
+		},
+		{
+			name:        "Multiple values",
+			vals:        []int{1, 2, 3},
+			wantVals:    []int{1, 2, 3},
+			wantReverse: []int{3, 2, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.wantVals, Collect(FromSlice(test.vals)))
+			assert.Equal(t, test.wantReverse, Collect(FromSliceReverse(test.vals)))
+		})
+	}
+}
+
+func TestFromFunc(t *testing.T) {
+	calls := 0
+	it := FromFunc(func() (int, bool) {
+		calls++
+		if calls > 2 {
+			return 0, false
+		}
+		return calls * 10, true
+	})
+
+	assert.True(t, it.Next())
+	assert.Equal(t, 10, it.Value())
+	assert.True(t, it.Next())
+	assert.Equal(t, 20, it.Value())
+	assert.False(t, it.Next())
+	// 遍历结束之后不会再调用 next
+	assert.False(t, it.Next())
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 0, it.Value())
+}
@@ -10,7 +10,11 @@
 package list
 
 import (
+	"slices"
+
+	genericgo "github.com/HJH0924/GenericGo"
 	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/iterator"
 	"github.com/HJH0924/GenericGo/slice"
 )
 
@@ -19,7 +23,9 @@ import (
 // 这个断言是编译时检查，确保 ArrayList 的实现符合 List 接口规范
 var (
 	// _ 是一个特殊的变量名，用于忽略未使用的变量值。
-	_ List[any] = &ArrayList[any]{}
+	_ List[any]                     = &ArrayList[any]{}
+	_ BulkList[any]                 = &ArrayList[any]{}
+	_ iterator.ReverseIterable[any] = &ArrayList[any]{}
 )
 
 // ArrayList 基于切片的简单封装
@@ -91,6 +97,103 @@ func (al *ArrayList[T]) Range(onVal func(idx int, val T) error) error {
 	return nil
 }
 
+// RemoveIf 删除所有满足 match 的元素，并返回被删除的元素个数。
+// 只需要遍历一次并原地移动保留的元素，时间复杂度为 O(n)，可能会发生缩容。
+func (al *ArrayList[T]) RemoveIf(match func(val T) bool) int {
+	kept := 0
+	for _, val := range al.vals {
+		if !match(val) {
+			al.vals[kept] = val
+			kept++
+		}
+	}
+	removed := len(al.vals) - kept
+	// 清空尾部的元素，避免继续持有它们的引用
+	clear(al.vals[kept:])
+	al.vals = slice.ShrinkSlice(al.vals[:kept])
+	return removed
+}
+
+// InsertAll 在特定下标处按顺序插入多个元素，只需要移动一次后续元素。
+// 如果下标超出合法范围，返回错误。
+// 如果 idx 等于 ArrayList 长度，则表示往 ArrayList 末端追加元素。
+func (al *ArrayList[T]) InsertAll(idx int, vals ...T) error {
+	length := al.Len()
+	if idx < 0 || idx > length {
+		return errs.NewErrIndexOutOfRange(length, idx)
+	}
+	al.vals = slices.Insert(al.vals, idx, vals...)
+	return nil
+}
+
+// Clear 删除 ArrayList 中的所有元素，保留已经分配的容量。
+func (al *ArrayList[T]) Clear() {
+	clear(al.vals)
+	al.vals = al.vals[:0]
+}
+
+// IndexOf 返回第一个与 val 相等的元素的下标，使用 equal 判断两个元素是否相等。
+// 如果不存在，返回 -1。
+func (al *ArrayList[T]) IndexOf(val T, equal func(left, right T) bool) int {
+	for i, v := range al.vals {
+		if equal(v, val) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Contains 判断 ArrayList 中是否存在与 val 相等的元素，使用 equal 判断两个元素是否相等。
+func (al *ArrayList[T]) Contains(val T, equal func(left, right T) bool) bool {
+	return al.IndexOf(val, equal) != -1
+}
+
+// Swap 交换下标 i 和 j 处的元素。
+// 如果下标超出合法范围，返回错误。
+func (al *ArrayList[T]) Swap(i, j int) error {
+	length := al.Len()
+	if i < 0 || i >= length {
+		return errs.NewErrIndexOutOfRange(length, i)
+	}
+	if j < 0 || j >= length {
+		return errs.NewErrIndexOutOfRange(length, j)
+	}
+	al.vals[i], al.vals[j] = al.vals[j], al.vals[i]
+	return nil
+}
+
+// Sort 使用 compare 将 ArrayList 按照从小到大的顺序原地排序，排序不稳定。
+func (al *ArrayList[T]) Sort(compare genericgo.Comparator[T]) {
+	slices.SortFunc(al.vals, compare)
+}
+
+// SortStable 使用 compare 将 ArrayList 按照从小到大的顺序原地排序，相等元素保持原来的相对顺序。
+func (al *ArrayList[T]) SortStable(compare genericgo.Comparator[T]) {
+	slices.SortStableFunc(al.vals, compare)
+}
+
+// BinarySearch 在按照 compare 从小到大排好序的 ArrayList 中二分查找 target，时间复杂度为 O(log n)。
+// 返回 target 所在的下标，如果不存在，返回 target 应当插入的位置，第二个返回值表示是否找到。
+func (al *ArrayList[T]) BinarySearch(target T, compare genericgo.Comparator[T]) (int, bool) {
+	return slices.BinarySearchFunc(al.vals, target, compare)
+}
+
+// SubList 返回 ArrayList 中下标在 [from, to) 之间的元素的视图，对视图的修改会反映到 ArrayList 中。
+// 如果下标超出合法范围，返回错误。
+func (al *ArrayList[T]) SubList(from, to int) (*SubList[T], error) {
+	return NewSubList[T](al, from, to)
+}
+
+// Iterator 返回从前往后遍历 ArrayList 的迭代器，不会复制底层切片。
+func (al *ArrayList[T]) Iterator() iterator.Iterator[T] {
+	return iterator.FromSlice(al.vals)
+}
+
+// ReverseIterator 返回从后往前遍历 ArrayList 的迭代器，不会复制底层切片。
+func (al *ArrayList[T]) ReverseIterator() iterator.Iterator[T] {
+	return iterator.FromSliceReverse(al.vals)
+}
+
 // AsSlice 将 ArrayList 转化为一个新切片，即使 ArrayList 为 nil，也返回一个长度和容量都为0的切片。
 // 由于返回的是新切片，对返回的切片所做的任何修改都不会影响原始的 ArrayList。
 // 但是如果 al.vals 为空，即 []T{}，则返回的 res 新切片 []T{} ，地址与 al.vals 相同，此时共用底层数组
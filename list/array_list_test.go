@@ -10,11 +10,13 @@
 package list
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/iterator"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -553,3 +555,358 @@ func TestArrayList_AsSlice(t *testing.T) {
 		})
 	}
 }
+
+func TestArrayList_Iterator(t *testing.T) {
+	tests := []struct {
+		name        string
+		list        *ArrayList[int]
+		wantVals    []int
+		wantReverse []int
+	}{
+		{
+			name:        "Empty list",
+			list:        NewArrayList[int](0),
+			wantVals:    []int{},
+			wantReverse: []int{},
+		},
+		{
+			name:        "Multiple values",
+			list:        NewArrayListOf[int]([]int{1, 2, 3}),
+			wantVals:    []int{1, 2, 3},
+			wantReverse: []int{3, 2, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantVals, iterator.Collect(tt.list.Iterator()))
+			assert.Equal(t, tt.wantReverse, iterator.Collect(tt.list.ReverseIterator()))
+		})
+	}
+}
+
+func TestArrayList_Sort(t *testing.T) {
+	tests := []struct {
+		name     string
+		vals     []int
+		wantVals []int
+	}{
+		{
+			name:     "Empty list",
+			vals:     []int{},
+			wantVals: []int{},
+		},
+		{
+			name:     "Single value",
+			vals:     []int{1},
+			wantVals: []int{1},
+		},
+		{
+			name:     "Unsorted values",
+			vals:     []int{5, 3, 9, 1, 3, 7, 2},
+			wantVals: []int{1, 2, 3, 3, 5, 7, 9},
+		},
+		{
+			name:     "Reversed values",
+			vals:     []int{4, 3, 2, 1},
+			wantVals: []int{1, 2, 3, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vals := make([]int, len(tt.vals))
+			copy(vals, tt.vals)
+			l := NewArrayListOf[int](vals)
+			l.Sort(cmp.Compare[int])
+			assert.Equal(t, tt.wantVals, l.AsSlice())
+
+			copy(vals, tt.vals)
+			l = NewArrayListOf[int](vals)
+			l.SortStable(cmp.Compare[int])
+			assert.Equal(t, tt.wantVals, l.AsSlice())
+			assert.Equal(t, tt.wantVals, iterator.Collect(l.Iterator()))
+		})
+	}
+}
+
+func TestArrayList_SortStable(t *testing.T) {
+	type item struct {
+		key   int
+		order int
+	}
+	vals := []item{{2, 0}, {1, 1}, {2, 2}, {1, 3}, {0, 4}, {2, 5}}
+	l := NewArrayListOf[item](vals)
+	l.SortStable(func(a, b item) int {
+		return cmp.Compare(a.key, b.key)
+	})
+	assert.Equal(t, []item{{0, 4}, {1, 1}, {1, 3}, {2, 0}, {2, 2}, {2, 5}}, l.AsSlice())
+}
+
+func TestArrayList_BinarySearch(t *testing.T) {
+	tests := []struct {
+		name      string
+		vals      []int
+		target    int
+		wantIdx   int
+		wantFound bool
+	}{
+		{
+			name:    "Empty list",
+			vals:    []int{},
+			target:  1,
+			wantIdx: 0,
+		},
+		{
+			name:      "Found",
+			vals:      []int{1, 3, 5, 7},
+			target:    5,
+			wantIdx:   2,
+			wantFound: true,
+		},
+		{
+			name:    "Not found in middle",
+			vals:    []int{1, 3, 5, 7},
+			target:  4,
+			wantIdx: 2,
+		},
+		{
+			name:    "Greater than all",
+			vals:    []int{1, 3, 5, 7},
+			target:  8,
+			wantIdx: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vals := tt.vals
+			l := NewArrayListOf[int](vals)
+			idx, found := l.BinarySearch(tt.target, cmp.Compare[int])
+			assert.Equal(t, tt.wantIdx, idx)
+			assert.Equal(t, tt.wantFound, found)
+		})
+	}
+}
+
+func TestArrayList_RemoveIf(t *testing.T) {
+	tests := []struct {
+		name        string
+		vals        []int
+		match       func(val int) bool
+		wantRemoved int
+		wantSlice   []int
+	}{
+		{
+			name:        "Empty list",
+			vals:        []int{},
+			match:       func(val int) bool { return true },
+			wantRemoved: 0,
+			wantSlice:   []int{},
+		},
+		{
+			name:        "Remove even values",
+			vals:        []int{1, 2, 3, 4, 5, 6},
+			match:       func(val int) bool { return val%2 == 0 },
+			wantRemoved: 3,
+			wantSlice:   []int{1, 3, 5},
+		},
+		{
+			name:        "Remove nothing",
+			vals:        []int{1, 3, 5},
+			match:       func(val int) bool { return val > 10 },
+			wantRemoved: 0,
+			wantSlice:   []int{1, 3, 5},
+		},
+		{
+			name:        "Remove all",
+			vals:        []int{1, 2, 3},
+			match:       func(val int) bool { return true },
+			wantRemoved: 3,
+			wantSlice:   []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewArrayListOf[int](tt.vals)
+			assert.Equal(t, tt.wantRemoved, l.RemoveIf(tt.match))
+			assert.Equal(t, tt.wantSlice, l.AsSlice())
+			assert.Equal(t, len(tt.wantSlice), l.Len())
+		})
+	}
+}
+
+func TestArrayList_InsertAll(t *testing.T) {
+	tests := []struct {
+		name      string
+		vals      []int
+		idx       int
+		inserted  []int
+		wantSlice []int
+		wantErr   error
+	}{
+		{
+			name:      "Insert into empty list",
+			vals:      []int{},
+			idx:       0,
+			inserted:  []int{1, 2},
+			wantSlice: []int{1, 2},
+		},
+		{
+			name:      "Insert at head",
+			vals:      []int{3, 4},
+			idx:       0,
+			inserted:  []int{1, 2},
+			wantSlice: []int{1, 2, 3, 4},
+		},
+		{
+			name:      "Insert in middle",
+			vals:      []int{1, 4},
+			idx:       1,
+			inserted:  []int{2, 3},
+			wantSlice: []int{1, 2, 3, 4},
+		},
+		{
+			name:      "Insert at tail",
+			vals:      []int{1, 2},
+			idx:       2,
+			inserted:  []int{3, 4},
+			wantSlice: []int{1, 2, 3, 4},
+		},
+		{
+			name:      "Insert nothing",
+			vals:      []int{1, 2},
+			idx:       1,
+			inserted:  nil,
+			wantSlice: []int{1, 2},
+		},
+		{
+			name:      "Negative index",
+			vals:      []int{1, 2},
+			idx:       -1,
+			inserted:  []int{3},
+			wantSlice: []int{1, 2},
+			wantErr:   errs.NewErrIndexOutOfRange(2, -1),
+		},
+		{
+			name:      "Index out of range",
+			vals:      []int{1, 2},
+			idx:       3,
+			inserted:  []int{3},
+			wantSlice: []int{1, 2},
+			wantErr:   errs.NewErrIndexOutOfRange(2, 3),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewArrayListOf[int](tt.vals)
+			err := l.InsertAll(tt.idx, tt.inserted...)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantSlice, l.AsSlice())
+			assert.Equal(t, len(tt.wantSlice), l.Len())
+		})
+	}
+}
+
+func TestArrayList_Clear(t *testing.T) {
+	l := NewArrayListOf[int]([]int{1, 2, 3})
+	l.Clear()
+	assert.Equal(t, 0, l.Len())
+	assert.Equal(t, []int{}, l.AsSlice())
+
+	// 清空之后仍然可以正常使用
+	l.Append(4, 5)
+	assert.Equal(t, []int{4, 5}, l.AsSlice())
+}
+
+func TestArrayList_IndexOf(t *testing.T) {
+	equal := func(left, right int) bool { return left == right }
+	tests := []struct {
+		name      string
+		vals      []int
+		target    int
+		wantIdx   int
+		wantFound bool
+	}{
+		{
+			name:    "Empty list",
+			vals:    []int{},
+			target:  1,
+			wantIdx: -1,
+		},
+		{
+			name:      "First occurrence",
+			vals:      []int{1, 2, 3, 2},
+			target:    2,
+			wantIdx:   1,
+			wantFound: true,
+		},
+		{
+			name:    "Not found",
+			vals:    []int{1, 2, 3},
+			target:  4,
+			wantIdx: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewArrayListOf[int](tt.vals)
+			assert.Equal(t, tt.wantIdx, l.IndexOf(tt.target, equal))
+			assert.Equal(t, tt.wantFound, l.Contains(tt.target, equal))
+		})
+	}
+}
+
+func TestArrayList_Swap(t *testing.T) {
+	tests := []struct {
+		name      string
+		vals      []int
+		i         int
+		j         int
+		wantSlice []int
+		wantErr   error
+	}{
+		{
+			name:      "Swap head and tail",
+			vals:      []int{1, 2, 3, 4},
+			i:         0,
+			j:         3,
+			wantSlice: []int{4, 2, 3, 1},
+		},
+		{
+			name:      "Swap same index",
+			vals:      []int{1, 2, 3},
+			i:         1,
+			j:         1,
+			wantSlice: []int{1, 2, 3},
+		},
+		{
+			name:      "First index out of range",
+			vals:      []int{1, 2, 3},
+			i:         3,
+			j:         0,
+			wantSlice: []int{1, 2, 3},
+			wantErr:   errs.NewErrIndexOutOfRange(3, 3),
+		},
+		{
+			name:      "Second index out of range",
+			vals:      []int{1, 2, 3},
+			i:         0,
+			j:         -1,
+			wantSlice: []int{1, 2, 3},
+			wantErr:   errs.NewErrIndexOutOfRange(3, -1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewArrayListOf[int](tt.vals)
+			err := l.Swap(tt.i, tt.j)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantSlice, l.AsSlice())
+		})
+	}
+}
@@ -9,10 +9,16 @@
 
 package list
 
-import "sync"
+import (
+	"errors"
+	"sync"
+
+	"github.com/HJH0924/GenericGo/errs"
+)
 
 var (
-	_ List[any] = &ConcurrentList[any]{}
+	_ List[any]     = &ConcurrentList[any]{}
+	_ BulkList[any] = &ConcurrentList[any]{}
 )
 
 // ConcurrentList 是一个线程安全的 List 接口包装器，它嵌入了泛型 List 接口，
@@ -83,6 +89,95 @@ func (cl *ConcurrentList[T]) Range(onVal func(idx int, val T) error) error {
 	return cl.List.Range(onVal)
 }
 
+// RemoveIf 删除所有满足 match 的元素，并返回被删除的元素个数。
+// 如果被包装的 List 实现了 BulkList，则直接使用它的实现，否则从后往前逐个删除。
+func (cl *ConcurrentList[T]) RemoveIf(match func(val T) bool) int {
+	cl.rwLock.Lock() // 写锁，确保独占访问
+	defer cl.rwLock.Unlock()
+	if bl, ok := cl.List.(BulkList[T]); ok {
+		return bl.RemoveIf(match)
+	}
+	removed := 0
+	for i := cl.List.Len() - 1; i >= 0; i-- {
+		val, err := cl.List.Get(i)
+		if err == nil && match(val) {
+			if _, err = cl.List.Delete(i); err == nil {
+				removed++
+			}
+		}
+	}
+	return removed
+}
+
+// InsertAll 在特定下标处按顺序插入多个元素。
+// 如果下标超出合法范围，返回错误。
+// 如果 idx 等于 ConcurrentList 长度，则表示往 ConcurrentList 末端追加元素。
+func (cl *ConcurrentList[T]) InsertAll(idx int, vals ...T) error {
+	cl.rwLock.Lock() // 写锁，确保独占访问
+	defer cl.rwLock.Unlock()
+	if bl, ok := cl.List.(BulkList[T]); ok {
+		return bl.InsertAll(idx, vals...)
+	}
+	if length := cl.List.Len(); idx < 0 || idx > length {
+		return errs.NewErrIndexOutOfRange(length, idx)
+	}
+	for i, val := range vals {
+		if err := cl.List.Add(idx+i, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Clear 删除 ConcurrentList 中的所有元素。
+func (cl *ConcurrentList[T]) Clear() {
+	cl.rwLock.Lock() // 写锁，确保独占访问
+	defer cl.rwLock.Unlock()
+	if bl, ok := cl.List.(BulkList[T]); ok {
+		bl.Clear()
+		return
+	}
+	for i := cl.List.Len() - 1; i >= 0; i-- {
+		_, _ = cl.List.Delete(i)
+	}
+}
+
+// IndexOf 返回第一个与 val 相等的元素的下标，使用 equal 判断两个元素是否相等。
+// 如果不存在，返回 -1。
+func (cl *ConcurrentList[T]) IndexOf(val T, equal func(left, right T) bool) int {
+	cl.rwLock.RLock() // 读锁，允许多个读操作
+	defer cl.rwLock.RUnlock()
+	return cl.indexOf(val, equal)
+}
+
+// Contains 判断 ConcurrentList 中是否存在与 val 相等的元素，使用 equal 判断两个元素是否相等。
+func (cl *ConcurrentList[T]) Contains(val T, equal func(left, right T) bool) bool {
+	cl.rwLock.RLock() // 读锁，允许多个读操作
+	defer cl.rwLock.RUnlock()
+	return cl.indexOf(val, equal) != -1
+}
+
+// Swap 交换下标 i 和 j 处的元素。
+// 如果下标超出合法范围，返回错误。
+func (cl *ConcurrentList[T]) Swap(i, j int) error {
+	cl.rwLock.Lock() // 写锁，确保独占访问
+	defer cl.rwLock.Unlock()
+	if bl, ok := cl.List.(BulkList[T]); ok {
+		return bl.Swap(i, j)
+	}
+	vi, err := cl.List.Get(i)
+	if err != nil {
+		return err
+	}
+	vj, err := cl.List.Get(j)
+	if err != nil {
+		return err
+	}
+	_ = cl.List.Set(i, vj)
+	_ = cl.List.Set(j, vi)
+	return nil
+}
+
 // AsSlice 将 ConcurrentList 转化为一个新切片，即使 ConcurrentList 为空，也返回一个长度和容量都为0的切片。
 func (cl *ConcurrentList[T]) AsSlice() []T {
 	cl.rwLock.RLock() // 读锁，允许多个读操作
@@ -96,3 +191,22 @@ func NewConcurrentListOf[T any](list List[T]) *ConcurrentList[T] {
 		List: list,
 	}
 }
+
+// indexOf 在持有锁的情况下查找第一个与 val 相等的元素的下标
+func (cl *ConcurrentList[T]) indexOf(val T, equal func(left, right T) bool) int {
+	if bl, ok := cl.List.(BulkList[T]); ok {
+		return bl.IndexOf(val, equal)
+	}
+	res := -1
+	err := cl.List.Range(func(idx int, v T) error {
+		if equal(v, val) {
+			res = idx
+			return errStopRange
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopRange) {
+		return -1
+	}
+	return res
+}
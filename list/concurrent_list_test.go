@@ -11,6 +11,7 @@ package list
 
 import (
 	"fmt"
+	"sync"
 	"testing"
 
 	"errors"
@@ -564,3 +565,57 @@ func TestConcurrentList_AsSlice(t *testing.T) {
 		})
 	}
 }
+
+func TestConcurrentList_BulkOperations(t *testing.T) {
+	equal := func(left, right int) bool { return left == right }
+	// ArrayList 实现了 BulkList，SkipList 没有实现，两者应当表现一致
+	lists := map[string]func(vals []int) *ConcurrentList[int]{
+		"BulkList": func(vals []int) *ConcurrentList[int] {
+			return NewConcurrentListOf[int](NewArrayListOf[int](vals))
+		},
+		"List": func(vals []int) *ConcurrentList[int] {
+			return NewConcurrentListOf[int](NewSkipListOf[int](vals))
+		},
+	}
+
+	for name, newList := range lists {
+		t.Run(name, func(t *testing.T) {
+			cl := newList([]int{1, 2, 3, 4, 5, 6})
+			assert.Equal(t, 3, cl.RemoveIf(func(val int) bool { return val%2 == 0 }))
+			assert.Equal(t, []int{1, 3, 5}, cl.AsSlice())
+
+			assert.NoError(t, cl.InsertAll(1, 10, 11))
+			assert.Equal(t, []int{1, 10, 11, 3, 5}, cl.AsSlice())
+			assert.Equal(t, errs.NewErrIndexOutOfRange(5, 6), cl.InsertAll(6, 12))
+
+			assert.Equal(t, 2, cl.IndexOf(11, equal))
+			assert.Equal(t, -1, cl.IndexOf(12, equal))
+			assert.True(t, cl.Contains(3, equal))
+			assert.False(t, cl.Contains(12, equal))
+
+			assert.NoError(t, cl.Swap(0, 4))
+			assert.Equal(t, []int{5, 10, 11, 3, 1}, cl.AsSlice())
+			assert.Equal(t, errs.NewErrIndexOutOfRange(5, 5), cl.Swap(0, 5))
+
+			cl.Clear()
+			assert.Equal(t, 0, cl.Len())
+			assert.Equal(t, []int{}, cl.AsSlice())
+		})
+	}
+}
+
+func TestConcurrentList_RemoveIfConcurrently(t *testing.T) {
+	cl := NewConcurrentListOf[int](NewLinkedList[int]())
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			cl.Append(i, -i-1)
+			cl.RemoveIf(func(val int) bool { return val < 0 })
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 0, cl.RemoveIf(func(val int) bool { return val < 0 }))
+	assert.Equal(t, 10, cl.Len())
+}
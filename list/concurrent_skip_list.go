@@ -0,0 +1,273 @@
+// Package list
+/**
+* @Project : GenericGo
+* @File    : concurrent_skip_list.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/22 10:08
+**/
+
+package list
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	genericgo "github.com/HJH0924/GenericGo"
+)
+
+// concurrentSkipListNode 是并发跳表的节点
+// key 和 topLevel 在节点发布之后不会再修改，其余字段都通过原子操作或节点锁访问
+type concurrentSkipListNode[K any, V any] struct {
+	key      K
+	val      atomic.Pointer[V]
+	nexts    []atomic.Pointer[concurrentSkipListNode[K, V]]
+	topLevel int
+
+	mutex       sync.Mutex  // 修改 nexts 之前需要持有的节点锁
+	marked      atomic.Bool // 节点已被逻辑删除
+	fullyLinked atomic.Bool // 节点已在所有层中链接完成
+}
+
+func newConcurrentSkipListNode[K any, V any](key K, val V, topLevel int) *concurrentSkipListNode[K, V] {
+	res := &concurrentSkipListNode[K, V]{
+		key:      key,
+		nexts:    make([]atomic.Pointer[concurrentSkipListNode[K, V]], topLevel),
+		topLevel: topLevel,
+	}
+	res.val.Store(&val)
+	return res
+}
+
+// ConcurrentSkipList 并发安全的有序跳表，提供与 SortedSkipList 相同的有序映射操作。
+// 与使用一把读写锁的 ConcurrentList 不同，它采用 Lazy Skip List 算法实现细粒度的并发控制：
+//   - Search、Contains 和遍历操作完全无锁；
+//   - Insert 和 Delete 只锁住需要修改的前驱节点，不同位置的写操作可以并行执行。
+//
+// 遍历操作是弱一致的，它不会因为并发修改而出错，但是可能看到也可能看不到遍历期间发生的修改。
+type ConcurrentSkipList[K any, V any] struct {
+	header  *concurrentSkipListNode[K, V] // 头节点，不存储键值对，拥有最大层数的索引
+	length  atomic.Int64
+	compare genericgo.Comparator[K]
+}
+
+// Insert 插入一个键值对，如果键已经存在，则更新其对应的值。
+// 返回 true 表示插入了新的键，false 表示更新了已有的键。
+func (Self *ConcurrentSkipList[K, V]) Insert(key K, val V) bool {
+	topLevel := randomLevel()
+	var preds, succs [skipListMaxLevel]*concurrentSkipListNode[K, V]
+	for {
+		if levelFound := Self.find(key, &preds, &succs); levelFound != -1 {
+			found := succs[levelFound]
+			if !found.marked.Load() {
+				// 等待其他协程完成插入，保证返回后该键一定可以被查询到
+				for !found.fullyLinked.Load() {
+					runtime.Gosched()
+				}
+				// Delete 持有节点锁做逻辑删除，在节点锁内再次确认节点没有被删除，避免更新写入一个正在被删除的节点而丢失
+				found.mutex.Lock()
+				if !found.marked.Load() {
+					found.val.Store(&val)
+					found.mutex.Unlock()
+					return false
+				}
+				found.mutex.Unlock()
+			}
+			// 节点正在被删除，重试
+			continue
+		}
+
+		// 自底向上锁住每一层的前驱节点，并校验前驱和后继没有发生变化
+		highestLocked, valid := -1, true
+		for level := 0; valid && level < topLevel; level++ {
+			pred, succ := preds[level], succs[level]
+			if level == 0 || pred != preds[level-1] {
+				pred.mutex.Lock()
+				highestLocked = level
+			}
+			valid = !pred.marked.Load() && (succ == nil || !succ.marked.Load()) && pred.nexts[level].Load() == succ
+		}
+		if !valid {
+			unlockPreds(&preds, highestLocked)
+			continue
+		}
+
+		newNode := newConcurrentSkipListNode(key, val, topLevel)
+		for level := 0; level < topLevel; level++ {
+			newNode.nexts[level].Store(succs[level])
+		}
+		for level := 0; level < topLevel; level++ {
+			preds[level].nexts[level].Store(newNode)
+		}
+		newNode.fullyLinked.Store(true)
+		unlockPreds(&preds, highestLocked)
+		Self.length.Add(1)
+		return true
+	}
+}
+
+// Search 返回键对应的值，第二个返回值表示键是否存在。该操作不会加锁。
+func (Self *ConcurrentSkipList[K, V]) Search(key K) (V, bool) {
+	var preds, succs [skipListMaxLevel]*concurrentSkipListNode[K, V]
+	levelFound := Self.find(key, &preds, &succs)
+	if levelFound == -1 {
+		return genericgo.Zero[V](), false
+	}
+	found := succs[levelFound]
+	if !found.fullyLinked.Load() || found.marked.Load() {
+		return genericgo.Zero[V](), false
+	}
+	return *found.val.Load(), true
+}
+
+// Contains 判断 ConcurrentSkipList 中是否包含指定的键。该操作不会加锁。
+func (Self *ConcurrentSkipList[K, V]) Contains(key K) bool {
+	_, ok := Self.Search(key)
+	return ok
+}
+
+// Delete 删除键对应的键值对，并返回被删除的值，第二个返回值表示键是否存在。
+func (Self *ConcurrentSkipList[K, V]) Delete(key K) (V, bool) {
+	var (
+		preds, succs [skipListMaxLevel]*concurrentSkipListNode[K, V]
+		victim       *concurrentSkipListNode[K, V]
+		isMarked     bool
+	)
+	for {
+		levelFound := Self.find(key, &preds, &succs)
+		if !isMarked {
+			if levelFound == -1 || !canDelete(succs[levelFound], levelFound) {
+				return genericgo.Zero[V](), false
+			}
+			// 先锁住待删除的节点并做逻辑删除，之后该节点不会再被其他协程删除或更新
+			victim = succs[levelFound]
+			victim.mutex.Lock()
+			if victim.marked.Load() {
+				victim.mutex.Unlock()
+				return genericgo.Zero[V](), false
+			}
+			victim.marked.Store(true)
+			isMarked = true
+		}
+
+		highestLocked, valid := -1, true
+		for level := 0; valid && level < victim.topLevel; level++ {
+			pred := preds[level]
+			if level == 0 || pred != preds[level-1] {
+				pred.mutex.Lock()
+				highestLocked = level
+			}
+			valid = !pred.marked.Load() && pred.nexts[level].Load() == victim
+		}
+		if !valid {
+			unlockPreds(&preds, highestLocked)
+			continue
+		}
+
+		// 自顶向下做物理删除
+		for level := victim.topLevel - 1; level >= 0; level-- {
+			preds[level].nexts[level].Store(victim.nexts[level].Load())
+		}
+		victim.mutex.Unlock()
+		unlockPreds(&preds, highestLocked)
+		Self.length.Add(-1)
+		return *victim.val.Load(), true
+	}
+}
+
+// Len 返回 ConcurrentSkipList 中键值对的数量。
+func (Self *ConcurrentSkipList[K, V]) Len() int {
+	return int(Self.length.Load())
+}
+
+// Keys 按照从小到大的顺序返回所有的键，即使跳表为空，也返回一个长度为0的切片。
+func (Self *ConcurrentSkipList[K, V]) Keys() []K {
+	res := make([]K, 0, Self.Len())
+	Self.Ascend(func(key K, val V) bool {
+		res = append(res, key)
+		return true
+	})
+	return res
+}
+
+// Ascend 按照键从小到大的顺序遍历所有的键值对，visit 返回 false 时停止遍历。
+func (Self *ConcurrentSkipList[K, V]) Ascend(visit func(key K, val V) bool) {
+	Self.visitFrom(Self.header.nexts[0].Load(), visit)
+}
+
+// Range 按照键从小到大的顺序遍历 from 和 to 之间的键值对，visit 返回 false 时停止遍历。
+// fromInclusive 和 toInclusive 分别表示是否包含 from 和 to 这两个端点。
+func (Self *ConcurrentSkipList[K, V]) Range(from K, fromInclusive bool, to K, toInclusive bool, visit func(key K, val V) bool) {
+	var preds, succs [skipListMaxLevel]*concurrentSkipListNode[K, V]
+	Self.find(from, &preds, &succs)
+	p := succs[0]
+	if p != nil && !fromInclusive && Self.compare(p.key, from) == 0 {
+		p = p.nexts[0].Load()
+	}
+	Self.visitFrom(p, func(key K, val V) bool {
+		cmp := Self.compare(key, to)
+		if cmp > 0 || (cmp == 0 && !toInclusive) {
+			return false
+		}
+		return visit(key, val)
+	})
+}
+
+// visitFrom 从节点 p 开始沿最底层遍历，跳过已被删除或尚未完成插入的节点
+func (Self *ConcurrentSkipList[K, V]) visitFrom(p *concurrentSkipListNode[K, V], visit func(key K, val V) bool) {
+	for ; p != nil; p = p.nexts[0].Load() {
+		if p.marked.Load() || !p.fullyLinked.Load() {
+			continue
+		}
+		if !visit(p.key, *p.val.Load()) {
+			return
+		}
+	}
+}
+
+// find 在每一层中查找最后一个键小于 key 的节点及其后继，分别存入 preds 和 succs。
+// 返回键等于 key 的节点所在的最高层，不存在时返回 -1。
+func (Self *ConcurrentSkipList[K, V]) find(key K, preds, succs *[skipListMaxLevel]*concurrentSkipListNode[K, V]) int {
+	levelFound := -1
+	pred := Self.header
+	for level := skipListMaxLevel - 1; level >= 0; level-- {
+		cur := pred.nexts[level].Load()
+		for cur != nil && Self.compare(cur.key, key) < 0 {
+			pred = cur
+			cur = pred.nexts[level].Load()
+		}
+		if levelFound == -1 && cur != nil && Self.compare(cur.key, key) == 0 {
+			levelFound = level
+		}
+		preds[level] = pred
+		succs[level] = cur
+	}
+	return levelFound
+}
+
+// canDelete 判断在第 levelFound 层找到的节点是否可以被删除
+// 节点需要已经完成插入，且是在它的最高层被找到的（否则说明找到的是一个正在插入的同名节点）
+func canDelete[K any, V any](node *concurrentSkipListNode[K, V], levelFound int) bool {
+	return node.fullyLinked.Load() && node.topLevel-1 == levelFound && !node.marked.Load()
+}
+
+// unlockPreds 释放第 0 到 highestLocked 层中被锁住的前驱节点，相同的前驱节点只会被解锁一次
+func unlockPreds[K any, V any](preds *[skipListMaxLevel]*concurrentSkipListNode[K, V], highestLocked int) {
+	for level := 0; level <= highestLocked; level++ {
+		if level == 0 || preds[level] != preds[level-1] {
+			preds[level].mutex.Unlock()
+		}
+	}
+}
+
+// NewConcurrentSkipList 创建一个空的 ConcurrentSkipList，compare 用于比较键的大小。
+func NewConcurrentSkipList[K any, V any](compare genericgo.Comparator[K]) *ConcurrentSkipList[K, V] {
+	return &ConcurrentSkipList[K, V]{
+		header: &concurrentSkipListNode[K, V]{
+			nexts:    make([]atomic.Pointer[concurrentSkipListNode[K, V]], skipListMaxLevel),
+			topLevel: skipListMaxLevel,
+		},
+		compare: compare,
+	}
+}
@@ -0,0 +1,274 @@
+// Package list
+/**
+* @Project : GenericGo
+* @File    : concurrent_skip_list_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/22 15:31
+**/
+
+package list
+
+import (
+	"cmp"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrentSkipList_InsertSearchDelete(t *testing.T) {
+	sl := NewConcurrentSkipList[int, string](cmp.Compare[int])
+	assert.True(t, sl.Insert(3, "c"))
+	assert.True(t, sl.Insert(1, "a"))
+	assert.True(t, sl.Insert(2, "b"))
+	assert.False(t, sl.Insert(3, "cc"))
+
+	assert.Equal(t, 3, sl.Len())
+	assert.Equal(t, []int{1, 2, 3}, sl.Keys())
+
+	val, ok := sl.Search(3)
+	assert.True(t, ok)
+	assert.Equal(t, "cc", val)
+	assert.False(t, sl.Contains(4))
+
+	val, ok = sl.Delete(2)
+	assert.True(t, ok)
+	assert.Equal(t, "b", val)
+	_, ok = sl.Delete(2)
+	assert.False(t, ok)
+	assert.Equal(t, []int{1, 3}, sl.Keys())
+}
+
+func TestConcurrentSkipList_Range(t *testing.T) {
+	sl := NewConcurrentSkipList[int, int](cmp.Compare[int])
+	for _, key := range []int{50, 10, 40, 20, 30} {
+		sl.Insert(key, key)
+	}
+
+	tests := []struct {
+		name          string
+		from          int
+		fromInclusive bool
+		to            int
+		toInclusive   bool
+		want          []int
+	}{
+		{name: "closed", from: 20, fromInclusive: true, to: 40, toInclusive: true, want: []int{20, 30, 40}},
+		{name: "open", from: 20, to: 40, want: []int{30}},
+		{name: "bounds not in list", from: 15, to: 45, want: []int{20, 30, 40}},
+		{name: "reversed bounds", from: 40, to: 20, want: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := make([]int, 0)
+			sl.Range(tt.from, tt.fromInclusive, tt.to, tt.toInclusive, func(key int, val int) bool {
+				res = append(res, key)
+				return true
+			})
+			assert.Equal(t, tt.want, res)
+		})
+	}
+}
+
+// 多个 goroutine 并发插入互不相同的键，完成后所有的键都应该存在且有序
+func TestConcurrentSkipList_ConcurrentInsert(t *testing.T) {
+	const (
+		numGoroutines        = 16
+		elementsPerGoroutine = 1000
+	)
+	sl := NewConcurrentSkipList[int, int](cmp.Compare[int])
+
+	var wg sync.WaitGroup
+	for i := 0; i < numGoroutines; i++ {
+		wg.Add(1)
+		go func(start int) {
+			defer wg.Done()
+			for _, key := range rand.Perm(elementsPerGoroutine) {
+				sl.Insert(start+key*numGoroutines, key)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	keys := sl.Keys()
+	require.Equal(t, numGoroutines*elementsPerGoroutine, sl.Len())
+	require.Equal(t, numGoroutines*elementsPerGoroutine, len(keys))
+	for i, key := range keys {
+		require.Equal(t, i, key)
+	}
+}
+
+// 多个 goroutine 并发插入、删除、查询和遍历相同范围内的键，
+// 每个 goroutine 只负责自己的一部分键的删除，完成后与预期的结果进行对比
+func TestConcurrentSkipList_ConcurrentMixed(t *testing.T) {
+	const (
+		numGoroutines = 8
+		numKeys       = 2000
+	)
+	sl := NewConcurrentSkipList[int, int](cmp.Compare[int])
+
+	var wg sync.WaitGroup
+	for i := 0; i < numGoroutines; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(id)))
+			for j := 0; j < numKeys; j++ {
+				key := r.Intn(numKeys)
+				switch r.Intn(4) {
+				case 0:
+					sl.Insert(key, key)
+				case 1:
+					// 每个 goroutine 只删除模 numGoroutines 等于 id 的键
+					if key%numGoroutines == id {
+						sl.Delete(key)
+					}
+				case 2:
+					if val, ok := sl.Search(key); ok {
+						assert.Equal(t, key, val)
+					}
+				default:
+					prev := -1
+					sl.Range(key, true, key+100, false, func(k int, v int) bool {
+						assert.Less(t, prev, k)
+						prev = k
+						return true
+					})
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	keys := sl.Keys()
+	assert.Equal(t, len(keys), sl.Len())
+	assert.True(t, sort.IntsAreSorted(keys))
+	for _, key := range keys {
+		val, ok := sl.Search(key)
+		assert.True(t, ok)
+		assert.Equal(t, key, val)
+	}
+}
+
+// 多个 goroutine 并发删除相同的键，每个键只能被成功删除一次
+func TestConcurrentSkipList_ConcurrentDelete(t *testing.T) {
+	const (
+		numGoroutines = 8
+		numKeys       = 5000
+	)
+	sl := NewConcurrentSkipList[int, int](cmp.Compare[int])
+	for i := 0; i < numKeys; i++ {
+		sl.Insert(i, i)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		deleted = make([]int, numGoroutines)
+	)
+	for i := 0; i < numGoroutines; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for key := 0; key < numKeys; key++ {
+				if _, ok := sl.Delete(key); ok {
+					deleted[id]++
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	total := 0
+	for _, cnt := range deleted {
+		total += cnt
+	}
+	assert.Equal(t, numKeys, total)
+	assert.Equal(t, 0, sl.Len())
+	assert.Equal(t, []int{}, sl.Keys())
+}
+
+// 同一个键上的 Insert 和 Delete 并发执行，结果必须等价于两者按某种顺序串行执行：
+//   - Insert 先执行：更新已有的键，Delete 返回更新后的值，之后键不存在；
+//   - Delete 先执行：Delete 返回原来的值，Insert 插入新的键，之后键存在。
+func TestConcurrentSkipList_ConcurrentInsertDelete(t *testing.T) {
+	const (
+		numKeys   = 8
+		numRounds = 2000
+	)
+	sl := NewConcurrentSkipList[int, int](cmp.Compare[int])
+
+	var wg sync.WaitGroup
+	for key := 0; key < numKeys; key++ {
+		wg.Add(1)
+		go func(key int) {
+			defer wg.Done()
+			for round := 0; round < numRounds; round++ {
+				sl.Insert(key, -1)
+
+				var (
+					inner    sync.WaitGroup
+					inserted bool
+					delVal   int
+					delOk    bool
+				)
+				inner.Add(2)
+				go func() {
+					defer inner.Done()
+					inserted = sl.Insert(key, round)
+				}()
+				go func() {
+					defer inner.Done()
+					delVal, delOk = sl.Delete(key)
+				}()
+				inner.Wait()
+
+				val, ok := sl.Search(key)
+				if !assert.True(t, delOk) {
+					return
+				}
+				if inserted {
+					assert.Equal(t, -1, delVal)
+					assert.True(t, ok)
+					assert.Equal(t, round, val)
+				} else {
+					if !assert.Equal(t, round, delVal, "update of a deleted key is lost") {
+						return
+					}
+					assert.False(t, ok)
+				}
+			}
+		}(key)
+	}
+	wg.Wait()
+}
+
+func BenchmarkConcurrentSkipList_Insert(b *testing.B) {
+	sl := NewConcurrentSkipList[int, int](cmp.Compare[int])
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewSource(rand.Int63()))
+		for pb.Next() {
+			key := r.Int()
+			sl.Insert(key, key)
+		}
+	})
+}
+
+// BenchmarkSortedSkipListWithMutex_Insert 作为对照组，使用一把互斥锁保护 SortedSkipList
+func BenchmarkSortedSkipListWithMutex_Insert(b *testing.B) {
+	var mutex sync.Mutex
+	sl := NewSortedSkipList[int, int](cmp.Compare[int])
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewSource(rand.Int63()))
+		for pb.Next() {
+			key := r.Int()
+			mutex.Lock()
+			sl.Insert(key, key)
+			mutex.Unlock()
+		}
+	})
+}
@@ -0,0 +1,227 @@
+// Package list
+/**
+* @Project : GenericGo
+* @File    : copy_on_write_list.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/9 10:15
+**/
+
+package list
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/iterator"
+)
+
+var (
+	_ List[any]              = &CopyOnWriteList[any]{}
+	_ BulkList[any]          = &CopyOnWriteList[any]{}
+	_ iterator.Iterable[any] = &CopyOnWriteList[any]{}
+)
+
+// CopyOnWriteList 基于写时复制的并发安全 List，适用于读多写少的场景，例如路由表和功能开关。
+// 底层切片一旦发布就不会再被修改，读操作通过 atomic.Pointer 获取当前切片的快照，完全无锁；
+// 写操作之间通过互斥锁串行执行，每次写操作都会复制整个切片，修改副本后再原子地发布，时间复杂度为 O(n)。
+//
+// 与 ConcurrentList 相比，读操作不会因为竞争读写锁而互相影响，也不会被写操作阻塞，
+// 但是频繁写入的代价很高，写多的场景应当使用 ConcurrentList。
+type CopyOnWriteList[T any] struct {
+	vals  atomic.Pointer[[]T] // 当前发布的切片，发布之后不会再被修改
+	mutex sync.Mutex          // 保证写操作串行执行
+}
+
+// Append 在 CopyOnWriteList 末尾追加一个或多个元素。
+func (Self *CopyOnWriteList[T]) Append(vals ...T) {
+	if len(vals) == 0 {
+		return
+	}
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	Self.publish(slices.Concat(Self.snapshot(), vals))
+}
+
+// Add 在特定下标处增加一个新元素。
+// 如果下标超出合法范围，返回错误。
+// 如果 idx 等于 CopyOnWriteList 长度，则表示往 CopyOnWriteList 末端增加元素。
+func (Self *CopyOnWriteList[T]) Add(idx int, val T) error {
+	return Self.InsertAll(idx, val)
+}
+
+// Delete 删除指定下标的元素，并返回被删除的元素。
+// 如果下标超出合法范围，返回错误。
+func (Self *CopyOnWriteList[T]) Delete(idx int) (T, error) {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	vals := Self.snapshot()
+	if idx < 0 || idx >= len(vals) {
+		var zero T
+		return zero, errs.NewErrIndexOutOfRange(len(vals), idx)
+	}
+	Self.publish(slices.Concat(vals[:idx], vals[idx+1:]))
+	return vals[idx], nil
+}
+
+// Set 重置指定下标位置的元素为 val。
+// 如果下标超出合法范围，返回错误。
+func (Self *CopyOnWriteList[T]) Set(idx int, val T) error {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	vals := Self.snapshot()
+	if idx < 0 || idx >= len(vals) {
+		return errs.NewErrIndexOutOfRange(len(vals), idx)
+	}
+	newVals := slices.Clone(vals)
+	newVals[idx] = val
+	Self.publish(newVals)
+	return nil
+}
+
+// Get 返回对应下标的元素，该操作不会加锁。
+// 如果下标超出合法范围，返回错误。
+func (Self *CopyOnWriteList[T]) Get(idx int) (val T, err error) {
+	vals := Self.snapshot()
+	if idx < 0 || idx >= len(vals) {
+		return val, errs.NewErrIndexOutOfRange(len(vals), idx)
+	}
+	return vals[idx], nil
+}
+
+// Len 返回 CopyOnWriteList 中元素的数量，该操作不会加锁。
+func (Self *CopyOnWriteList[T]) Len() int {
+	return len(Self.snapshot())
+}
+
+// Cap 返回 CopyOnWriteList 的容量。
+// 每次写操作都会分配恰好容纳所有元素的新切片，因此容量等于其长度。
+func (Self *CopyOnWriteList[T]) Cap() int {
+	return Self.Len()
+}
+
+// Range 遍历 CopyOnWriteList 的所有元素，并使用给定的函数访问每个元素，该操作不会加锁。
+// 遍历的是调用时的快照，遍历期间发生的修改不会被看到，onVal 中也可以安全地修改 CopyOnWriteList。
+func (Self *CopyOnWriteList[T]) Range(onVal func(idx int, val T) error) error {
+	for idx, val := range Self.snapshot() {
+		if err := onVal(idx, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Iterator 返回遍历调用时快照的迭代器，该操作不会加锁，也不会复制底层切片。
+func (Self *CopyOnWriteList[T]) Iterator() iterator.Iterator[T] {
+	return iterator.FromSlice(Self.snapshot())
+}
+
+// AsSlice 将 CopyOnWriteList 转化为一个新切片，即使 CopyOnWriteList 为空，也返回一个长度和容量都为0的切片。
+// 该操作不会加锁。
+func (Self *CopyOnWriteList[T]) AsSlice() []T {
+	vals := Self.snapshot()
+	res := make([]T, len(vals))
+	copy(res, vals)
+	return res
+}
+
+// RemoveIf 删除所有满足 match 的元素，并返回被删除的元素个数，只会复制一次底层切片。
+func (Self *CopyOnWriteList[T]) RemoveIf(match func(val T) bool) int {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	vals := Self.snapshot()
+	newVals := make([]T, 0, len(vals))
+	for _, val := range vals {
+		if !match(val) {
+			newVals = append(newVals, val)
+		}
+	}
+	removed := len(vals) - len(newVals)
+	if removed > 0 {
+		Self.publish(slices.Clip(newVals))
+	}
+	return removed
+}
+
+// InsertAll 在特定下标处按顺序插入多个元素，只会复制一次底层切片。
+// 如果下标超出合法范围，返回错误。
+// 如果 idx 等于 CopyOnWriteList 长度，则表示往 CopyOnWriteList 末端追加元素。
+func (Self *CopyOnWriteList[T]) InsertAll(idx int, vals ...T) error {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	oldVals := Self.snapshot()
+	if idx < 0 || idx > len(oldVals) {
+		return errs.NewErrIndexOutOfRange(len(oldVals), idx)
+	}
+	Self.publish(slices.Concat(oldVals[:idx], vals, oldVals[idx:]))
+	return nil
+}
+
+// Clear 删除 CopyOnWriteList 中的所有元素。
+func (Self *CopyOnWriteList[T]) Clear() {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	Self.publish(nil)
+}
+
+// IndexOf 返回第一个与 val 相等的元素的下标，使用 equal 判断两个元素是否相等，该操作不会加锁。
+// 如果不存在，返回 -1。
+func (Self *CopyOnWriteList[T]) IndexOf(val T, equal func(left, right T) bool) int {
+	for idx, v := range Self.snapshot() {
+		if equal(v, val) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// Contains 判断 CopyOnWriteList 中是否存在与 val 相等的元素，使用 equal 判断两个元素是否相等，该操作不会加锁。
+func (Self *CopyOnWriteList[T]) Contains(val T, equal func(left, right T) bool) bool {
+	return Self.IndexOf(val, equal) != -1
+}
+
+// Swap 交换下标 i 和 j 处的元素。
+// 如果下标超出合法范围，返回错误。
+func (Self *CopyOnWriteList[T]) Swap(i, j int) error {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	vals := Self.snapshot()
+	if i < 0 || i >= len(vals) {
+		return errs.NewErrIndexOutOfRange(len(vals), i)
+	}
+	if j < 0 || j >= len(vals) {
+		return errs.NewErrIndexOutOfRange(len(vals), j)
+	}
+	newVals := slices.Clone(vals)
+	newVals[i], newVals[j] = newVals[j], newVals[i]
+	Self.publish(newVals)
+	return nil
+}
+
+// snapshot 返回当前发布的切片，调用方不能修改返回的切片
+func (Self *CopyOnWriteList[T]) snapshot() []T {
+	if vals := Self.vals.Load(); vals != nil {
+		return *vals
+	}
+	return nil
+}
+
+// publish 发布新的切片，调用方需要持有 mutex，并且之后不能再修改 vals
+func (Self *CopyOnWriteList[T]) publish(vals []T) {
+	Self.vals.Store(&vals)
+}
+
+// NewCopyOnWriteList 创建并返回一个空的 CopyOnWriteList。
+func NewCopyOnWriteList[T any]() *CopyOnWriteList[T] {
+	return &CopyOnWriteList[T]{}
+}
+
+// NewCopyOnWriteListOf 创建一个新的 CopyOnWriteList，并按顺序包含 vals 中的元素。
+// 此函数会复制 vals，之后对 vals 的修改不会影响返回的 CopyOnWriteList。
+func NewCopyOnWriteListOf[T any](vals []T) *CopyOnWriteList[T] {
+	res := &CopyOnWriteList[T]{}
+	res.publish(slices.Clone(vals))
+	return res
+}
@@ -0,0 +1,291 @@
+// Package list
+/**
+* @Project : GenericGo
+* @File    : copy_on_write_list_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/9 11:00
+**/
+
+package list
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/iterator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyOnWriteList_Append(t *testing.T) {
+	tests := []struct {
+		name      string
+		vals      []int
+		inputVals []int
+		wantSlice []int
+	}{
+		{
+			name:      "Append to empty list",
+			vals:      nil,
+			inputVals: []int{1, 2},
+			wantSlice: []int{1, 2},
+		},
+		{
+			name:      "Append to non-empty list",
+			vals:      []int{1, 2},
+			inputVals: []int{3, 4},
+			wantSlice: []int{1, 2, 3, 4},
+		},
+		{
+			name:      "Append nothing",
+			vals:      []int{1, 2},
+			inputVals: nil,
+			wantSlice: []int{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewCopyOnWriteListOf(tt.vals)
+			l.Append(tt.inputVals...)
+			assert.Equal(t, tt.wantSlice, l.AsSlice())
+			assert.Equal(t, len(tt.wantSlice), l.Len())
+			assert.Equal(t, len(tt.wantSlice), l.Cap())
+		})
+	}
+}
+
+func TestCopyOnWriteList_Add(t *testing.T) {
+	tests := []struct {
+		name      string
+		vals      []int
+		idx       int
+		val       int
+		wantSlice []int
+		wantErr   error
+	}{
+		{
+			name:      "Add at head",
+			vals:      []int{2, 3},
+			idx:       0,
+			val:       1,
+			wantSlice: []int{1, 2, 3},
+		},
+		{
+			name:      "Add in middle",
+			vals:      []int{1, 3},
+			idx:       1,
+			val:       2,
+			wantSlice: []int{1, 2, 3},
+		},
+		{
+			name:      "Add at tail",
+			vals:      []int{1, 2},
+			idx:       2,
+			val:       3,
+			wantSlice: []int{1, 2, 3},
+		},
+		{
+			name:      "Index out of range",
+			vals:      []int{1, 2},
+			idx:       3,
+			wantSlice: []int{1, 2},
+			wantErr:   errs.NewErrIndexOutOfRange(2, 3),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewCopyOnWriteListOf(tt.vals)
+			err := l.Add(tt.idx, tt.val)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantSlice, l.AsSlice())
+		})
+	}
+}
+
+func TestCopyOnWriteList_Delete(t *testing.T) {
+	tests := []struct {
+		name      string
+		vals      []int
+		idx       int
+		wantVal   int
+		wantSlice []int
+		wantErr   error
+	}{
+		{
+			name:      "Delete head",
+			vals:      []int{1, 2, 3},
+			idx:       0,
+			wantVal:   1,
+			wantSlice: []int{2, 3},
+		},
+		{
+			name:      "Delete tail",
+			vals:      []int{1, 2, 3},
+			idx:       2,
+			wantVal:   3,
+			wantSlice: []int{1, 2},
+		},
+		{
+			name:      "Delete from empty list",
+			vals:      nil,
+			idx:       0,
+			wantSlice: []int{},
+			wantErr:   errs.NewErrIndexOutOfRange(0, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewCopyOnWriteListOf(tt.vals)
+			val, err := l.Delete(tt.idx)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantVal, val)
+			assert.Equal(t, tt.wantSlice, l.AsSlice())
+		})
+	}
+}
+
+func TestCopyOnWriteList_SetGet(t *testing.T) {
+	l := NewCopyOnWriteListOf([]int{1, 2, 3})
+	require.NoError(t, l.Set(1, 20))
+	val, err := l.Get(1)
+	require.NoError(t, err)
+	assert.Equal(t, 20, val)
+
+	assert.Equal(t, errs.NewErrIndexOutOfRange(3, 3), l.Set(3, 0))
+	_, err = l.Get(-1)
+	assert.Equal(t, errs.NewErrIndexOutOfRange(3, -1), err)
+}
+
+func TestCopyOnWriteList_Snapshot(t *testing.T) {
+	vals := []int{1, 2, 3}
+	l := NewCopyOnWriteListOf(vals)
+	// 构造时会复制 vals
+	vals[0] = 100
+	assert.Equal(t, []int{1, 2, 3}, l.AsSlice())
+
+	// 迭代器和 Range 遍历的是调用时的快照
+	it := l.Iterator()
+	var seen []int
+	err := l.Range(func(idx int, val int) error {
+		seen = append(seen, val)
+		l.Append(val)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, seen)
+	assert.Equal(t, []int{1, 2, 3}, iterator.Collect(it))
+	assert.Equal(t, []int{1, 2, 3, 1, 2, 3}, l.AsSlice())
+}
+
+func TestCopyOnWriteList_BulkOperations(t *testing.T) {
+	equal := func(left, right int) bool { return left == right }
+	l := NewCopyOnWriteList[int]()
+	assert.Equal(t, []int{}, l.AsSlice())
+
+	require.NoError(t, l.InsertAll(0, 1, 2, 3, 4, 5, 6))
+	assert.Equal(t, errs.NewErrIndexOutOfRange(6, 7), l.InsertAll(7, 7))
+	assert.Equal(t, 3, l.RemoveIf(func(val int) bool { return val%2 == 0 }))
+	assert.Equal(t, 0, l.RemoveIf(func(val int) bool { return val > 10 }))
+	assert.Equal(t, []int{1, 3, 5}, l.AsSlice())
+
+	assert.Equal(t, 1, l.IndexOf(3, equal))
+	assert.Equal(t, -1, l.IndexOf(4, equal))
+	assert.True(t, l.Contains(5, equal))
+	assert.False(t, l.Contains(6, equal))
+
+	require.NoError(t, l.Swap(0, 2))
+	assert.Equal(t, []int{5, 3, 1}, l.AsSlice())
+	assert.Equal(t, errs.NewErrIndexOutOfRange(3, 3), l.Swap(3, 0))
+	assert.Equal(t, errs.NewErrIndexOutOfRange(3, -1), l.Swap(0, -1))
+
+	l.Clear()
+	assert.Equal(t, 0, l.Len())
+	assert.Equal(t, []int{}, l.AsSlice())
+}
+
+func TestCopyOnWriteList_Concurrent(t *testing.T) {
+	l := NewCopyOnWriteList[int]()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				l.Append(i)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				// 读操作总是看到一个完整的快照
+				_ = l.Range(func(idx int, val int) error {
+					return nil
+				})
+				if n := l.Len(); n > 0 {
+					_, err := l.Get(n - 1)
+					assert.NoError(t, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 800, l.Len())
+}
+
+func BenchmarkCopyOnWriteList_Get(b *testing.B) {
+	l := NewCopyOnWriteListOf(makeRange(0, 1024))
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			_, _ = l.Get(i & 1023)
+			i++
+		}
+	})
+}
+
+func BenchmarkConcurrentList_Get(b *testing.B) {
+	l := NewConcurrentListOf[int](NewArrayListOf(makeRange(0, 1024)))
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			_, _ = l.Get(i & 1023)
+			i++
+		}
+	})
+}
+
+// 读多写少的场景：每 1000 次读操作对应一次写操作
+func BenchmarkCopyOnWriteList_ReadMostly(b *testing.B) {
+	l := NewCopyOnWriteListOf(makeRange(0, 1024))
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%1000 == 0 {
+				_ = l.Set(i&1023, i)
+			} else {
+				_, _ = l.Get(i & 1023)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkConcurrentList_ReadMostly(b *testing.B) {
+	l := NewConcurrentListOf[int](NewArrayListOf(makeRange(0, 1024)))
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%1000 == 0 {
+				_ = l.Set(i&1023, i)
+			} else {
+				_, _ = l.Get(i & 1023)
+			}
+			i++
+		}
+	})
+}
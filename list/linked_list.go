@@ -9,37 +9,64 @@
 
 package list
 
-import "github.com/HJH0924/GenericGo/errs"
+import (
+	genericgo "github.com/HJH0924/GenericGo"
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/iterator"
+)
 
 var (
-	_ List[any] = &LinkedList[any]{}
+	_ List[any]                     = &LinkedList[any]{}
+	_ BulkList[any]                 = &LinkedList[any]{}
+	_ iterator.ReverseIterable[any] = &LinkedList[any]{}
 )
 
-// node 定义双向循环链表的节点结构
-type node[T any] struct {
-	prev *node[T]
-	next *node[T]
+// Element 是 LinkedList 的节点，同时作为节点句柄返回给调用方。
+// 持有 Element 的调用方可以在 O(1) 时间内完成插入、移动和删除，而不需要通过下标从链表的一端开始查找节点。
+type Element[T any] struct {
+	prev *Element[T]
+	next *Element[T]
+	list *LinkedList[T] // 节点所属的链表，节点被删除之后为 nil
 	val  T
 }
 
+// Value 返回节点中存储的元素。
+func (e *Element[T]) Value() T {
+	return e.val
+}
+
+// SetValue 重置节点中存储的元素为 val，不会改变节点在链表中的位置。
+func (e *Element[T]) SetValue(val T) {
+	e.val = val
+}
+
+// Next 返回下一个节点，如果 e 是最后一个节点或者已经被删除，返回 nil。
+func (e *Element[T]) Next() *Element[T] {
+	if e.list == nil || e.next == e.list.tail {
+		return nil
+	}
+	return e.next
+}
+
+// Prev 返回上一个节点，如果 e 是第一个节点或者已经被删除，返回 nil。
+func (e *Element[T]) Prev() *Element[T] {
+	if e.list == nil || e.prev == e.list.head {
+		return nil
+	}
+	return e.prev
+}
+
 // LinkedList 定义双向循环链表结构
 type LinkedList[T any] struct {
-	head   *node[T]
-	tail   *node[T]
+	head   *Element[T]
+	tail   *Element[T]
 	length int
 }
 
 // Append 在 LinkedList 末尾追加一个或多个元素。
 func (ll *LinkedList[T]) Append(vals ...T) {
 	for _, val := range vals {
-		newNode := &node[T]{
-			prev: ll.tail.prev,
-			next: ll.tail,
-			val:  val,
-		}
-		ll.tail.prev = newNode
-		newNode.prev.next = newNode
-		ll.length++
+		ll.insertBefore(ll.tail, val)
 	}
 }
 
@@ -51,18 +78,10 @@ func (ll *LinkedList[T]) Add(idx int, val T) error {
 		return errs.NewErrIndexOutOfRange(ll.length, idx)
 	}
 	if idx == ll.length {
-		ll.Append(val)
+		ll.insertBefore(ll.tail, val)
 		return nil
 	}
-	p := ll.getNodeAt(idx)
-	newNode := &node[T]{
-		prev: p.prev,
-		next: p,
-		val:  val,
-	}
-	p.prev.next = newNode
-	p.prev = newNode
-	ll.length++
+	ll.insertBefore(ll.getNodeAt(idx), val)
 	return nil
 }
 
@@ -73,10 +92,7 @@ func (ll *LinkedList[T]) Delete(idx int) (deletedVal T, err error) {
 		return deletedVal, errs.NewErrIndexOutOfRange(ll.length, idx)
 	}
 	p := ll.getNodeAt(idx)
-	p.prev.next = p.next
-	p.next.prev = p.prev
-	p.prev, p.next = nil, nil
-	ll.length--
+	ll.unlink(p)
 	return p.val, nil
 }
 
@@ -124,6 +140,233 @@ func (ll *LinkedList[T]) Range(onVal func(idx int, val T) error) error {
 	return nil
 }
 
+// RemoveIf 删除所有满足 match 的元素，并返回被删除的元素个数。
+// 只需要遍历一次并摘除匹配的节点，时间复杂度为 O(n)。
+func (ll *LinkedList[T]) RemoveIf(match func(val T) bool) int {
+	removed := 0
+	for p := ll.head.next; p != ll.tail; {
+		next := p.next
+		if match(p.val) {
+			ll.unlink(p)
+			removed++
+		}
+		p = next
+	}
+	return removed
+}
+
+// InsertAll 在特定下标处按顺序插入多个元素，只需要定位一次插入位置。
+// 如果下标超出合法范围，返回错误。
+// 如果 idx 等于 LinkedList 长度，则表示往 LinkedList 末端追加元素。
+func (ll *LinkedList[T]) InsertAll(idx int, vals ...T) error {
+	if idx < 0 || idx > ll.length {
+		return errs.NewErrIndexOutOfRange(ll.length, idx)
+	}
+	// 新元素依次插入到 next 节点之前
+	next := ll.tail
+	if idx < ll.length {
+		next = ll.getNodeAt(idx)
+	}
+	for _, val := range vals {
+		ll.insertBefore(next, val)
+	}
+	return nil
+}
+
+// Clear 删除 LinkedList 中的所有元素。
+// 所有节点都会与 LinkedList 解除关联，之前返回的 Element 都将失效。
+func (ll *LinkedList[T]) Clear() {
+	for p := ll.head.next; p != ll.tail; {
+		next := p.next
+		p.prev, p.next, p.list = nil, nil, nil
+		p = next
+	}
+	ll.head.next = ll.tail
+	ll.tail.prev = ll.head
+	ll.length = 0
+}
+
+// IndexOf 返回第一个与 val 相等的元素的下标，使用 equal 判断两个元素是否相等。
+// 如果不存在，返回 -1。
+func (ll *LinkedList[T]) IndexOf(val T, equal func(left, right T) bool) int {
+	for p, i := ll.head.next, 0; i < ll.length; p, i = p.next, i+1 {
+		if equal(p.val, val) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Contains 判断 LinkedList 中是否存在与 val 相等的元素，使用 equal 判断两个元素是否相等。
+func (ll *LinkedList[T]) Contains(val T, equal func(left, right T) bool) bool {
+	return ll.IndexOf(val, equal) != -1
+}
+
+// Swap 交换下标 i 和 j 处的元素，只交换节点中的值，不调整节点之间的指针。
+// 如果下标超出合法范围，返回错误。
+func (ll *LinkedList[T]) Swap(i, j int) error {
+	if i < 0 || i >= ll.length {
+		return errs.NewErrIndexOutOfRange(ll.length, i)
+	}
+	if j < 0 || j >= ll.length {
+		return errs.NewErrIndexOutOfRange(ll.length, j)
+	}
+	p, q := ll.getNodeAt(i), ll.getNodeAt(j)
+	p.val, q.val = q.val, p.val
+	return nil
+}
+
+// Front 返回第一个节点，如果 LinkedList 为空，返回 nil。
+func (ll *LinkedList[T]) Front() *Element[T] {
+	if ll.length == 0 {
+		return nil
+	}
+	return ll.head.next
+}
+
+// Back 返回最后一个节点，如果 LinkedList 为空，返回 nil。
+func (ll *LinkedList[T]) Back() *Element[T] {
+	if ll.length == 0 {
+		return nil
+	}
+	return ll.tail.prev
+}
+
+// PushFront 在 LinkedList 头部插入一个新元素，并返回新元素所在的节点，时间复杂度为 O(1)。
+func (ll *LinkedList[T]) PushFront(val T) *Element[T] {
+	return ll.insertBefore(ll.head.next, val)
+}
+
+// PushBack 在 LinkedList 尾部插入一个新元素，并返回新元素所在的节点，时间复杂度为 O(1)。
+func (ll *LinkedList[T]) PushBack(val T) *Element[T] {
+	return ll.insertBefore(ll.tail, val)
+}
+
+// InsertBefore 在节点 mark 之前插入一个新元素，并返回新元素所在的节点，时间复杂度为 O(1)。
+// 如果 mark 不属于该 LinkedList，返回 errs.NewErrInvalidHandle。
+func (ll *LinkedList[T]) InsertBefore(val T, mark *Element[T]) (*Element[T], error) {
+	if !ll.owns(mark) {
+		return nil, errs.NewErrInvalidHandle()
+	}
+	return ll.insertBefore(mark, val), nil
+}
+
+// InsertAfter 在节点 mark 之后插入一个新元素，并返回新元素所在的节点，时间复杂度为 O(1)。
+// 如果 mark 不属于该 LinkedList，返回 errs.NewErrInvalidHandle。
+func (ll *LinkedList[T]) InsertAfter(val T, mark *Element[T]) (*Element[T], error) {
+	if !ll.owns(mark) {
+		return nil, errs.NewErrInvalidHandle()
+	}
+	return ll.insertBefore(mark.next, val), nil
+}
+
+// MoveToFront 将节点 e 移动到 LinkedList 头部，时间复杂度为 O(1)。
+// 如果 e 不属于该 LinkedList，返回 errs.NewErrInvalidHandle。
+func (ll *LinkedList[T]) MoveToFront(e *Element[T]) error {
+	if !ll.owns(e) {
+		return errs.NewErrInvalidHandle()
+	}
+	ll.move(e, ll.head)
+	return nil
+}
+
+// MoveToBack 将节点 e 移动到 LinkedList 尾部，时间复杂度为 O(1)。
+// 如果 e 不属于该 LinkedList，返回 errs.NewErrInvalidHandle。
+func (ll *LinkedList[T]) MoveToBack(e *Element[T]) error {
+	if !ll.owns(e) {
+		return errs.NewErrInvalidHandle()
+	}
+	ll.move(e, ll.tail.prev)
+	return nil
+}
+
+// Remove 删除节点 e，并返回其中存储的元素，时间复杂度为 O(1)。删除之后 e 将失效。
+// 如果 e 不属于该 LinkedList，返回 errs.NewErrInvalidHandle。
+func (ll *LinkedList[T]) Remove(e *Element[T]) (T, error) {
+	if !ll.owns(e) {
+		var zero T
+		return zero, errs.NewErrInvalidHandle()
+	}
+	ll.unlink(e)
+	return e.val, nil
+}
+
+// Sort 使用 compare 将 LinkedList 按照从小到大的顺序原地排序。
+// 链表使用归并排序，只调整节点之间的指针，不会移动元素，排序是稳定的，时间复杂度为 O(n log n)。
+func (ll *LinkedList[T]) Sort(compare genericgo.Comparator[T]) {
+	if ll.length < 2 {
+		return
+	}
+	// 断开头尾哨兵，只使用 next 指针对中间的节点进行归并排序
+	ll.tail.prev.next = nil
+	first := mergeSortNodes(ll.head.next, ll.length, compare)
+
+	// 重新连接 prev 指针和头尾哨兵
+	prev := ll.head
+	for p := first; p != nil; p = p.next {
+		prev.next = p
+		p.prev = prev
+		prev = p
+	}
+	prev.next = ll.tail
+	ll.tail.prev = prev
+}
+
+// SortStable 使用 compare 将 LinkedList 按照从小到大的顺序原地排序，相等元素保持原来的相对顺序。
+// 由于 Sort 使用的归并排序本身就是稳定的，二者的行为一致。
+func (ll *LinkedList[T]) SortStable(compare genericgo.Comparator[T]) {
+	ll.Sort(compare)
+}
+
+// SearchSorted 在按照 compare 从小到大排好序的 LinkedList 中查找 target。
+// 链表不支持随机访问，无法进行二分查找，这里从头开始顺序查找，
+// 遇到第一个不小于 target 的元素时停止，时间复杂度为 O(n)。
+// 返回 target 所在的下标，如果不存在，返回 target 应当插入的位置，第二个返回值表示是否找到。
+func (ll *LinkedList[T]) SearchSorted(target T, compare genericgo.Comparator[T]) (int, bool) {
+	i := 0
+	for p := ll.head.next; p != ll.tail; p = p.next {
+		if cmp := compare(p.val, target); cmp >= 0 {
+			return i, cmp == 0
+		}
+		i++
+	}
+	return i, false
+}
+
+// SubList 返回 LinkedList 中下标在 [from, to) 之间的元素的视图，对视图的修改会反映到 LinkedList 中。
+// 如果下标超出合法范围，返回错误。
+func (ll *LinkedList[T]) SubList(from, to int) (*SubList[T], error) {
+	return NewSubList[T](ll, from, to)
+}
+
+// Iterator 返回从头到尾遍历 LinkedList 的迭代器。
+func (ll *LinkedList[T]) Iterator() iterator.Iterator[T] {
+	p := ll.head.next
+	return iterator.FromFunc(func() (T, bool) {
+		if p == ll.tail {
+			var zero T
+			return zero, false
+		}
+		val := p.val
+		p = p.next
+		return val, true
+	})
+}
+
+// ReverseIterator 返回从尾到头遍历 LinkedList 的迭代器。
+func (ll *LinkedList[T]) ReverseIterator() iterator.Iterator[T] {
+	p := ll.tail.prev
+	return iterator.FromFunc(func() (T, bool) {
+		if p == ll.head {
+			var zero T
+			return zero, false
+		}
+		val := p.val
+		p = p.prev
+		return val, true
+	})
+}
+
 // AsSlice 将 LinkedList 转化为一个新切片，即使 LinkedList 为空，也返回一个长度和容量都为0的切片。
 func (ll *LinkedList[T]) AsSlice() []T {
 	res := make([]T, ll.length)
@@ -139,8 +382,8 @@ func (ll *LinkedList[T]) AsSlice() []T {
 // NewLinkedList 创建并返回一个新的LinkedList实例。
 func NewLinkedList[T any]() *LinkedList[T] {
 	// 创建头尾节点
-	head := &node[T]{}
-	tail := &node[T]{}
+	head := &Element[T]{}
+	tail := &Element[T]{}
 
 	// 相互指向
 	head.prev = tail
@@ -163,10 +406,51 @@ func NewLinkedListOf[T any](vals []T) *LinkedList[T] {
 	return ll
 }
 
+// owns 判断节点 e 是否属于该 LinkedList
+func (ll *LinkedList[T]) owns(e *Element[T]) bool {
+	return e != nil && e.list == ll
+}
+
+// insertBefore 在节点 mark 之前插入一个新元素，并返回新元素所在的节点，mark 可以是尾哨兵
+func (ll *LinkedList[T]) insertBefore(mark *Element[T], val T) *Element[T] {
+	newNode := &Element[T]{
+		prev: mark.prev,
+		next: mark,
+		list: ll,
+		val:  val,
+	}
+	mark.prev.next = newNode
+	mark.prev = newNode
+	ll.length++
+	return newNode
+}
+
+// unlink 将节点 e 从链表中摘除，并解除它与链表的关联
+func (ll *LinkedList[T]) unlink(e *Element[T]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.prev, e.next, e.list = nil, nil, nil
+	ll.length--
+}
+
+// move 将节点 e 移动到节点 at 之后，at 可以是头哨兵
+func (ll *LinkedList[T]) move(e *Element[T], at *Element[T]) {
+	if e == at || e == at.next {
+		return
+	}
+	e.prev.next = e.next
+	e.next.prev = e.prev
+
+	e.prev = at
+	e.next = at.next
+	at.next.prev = e
+	at.next = e
+}
+
 // getNodeAt 返回双向循环链表中索引为 i 的节点。
 // 因为该函数只供内部使用，所以在调用该函数之前已经确保了索引合法
-func (ll *LinkedList[T]) getNodeAt(idx int) *node[T] {
-	var p *node[T]
+func (ll *LinkedList[T]) getNodeAt(idx int) *Element[T] {
+	var p *Element[T]
 	if idx < ll.length/2 {
 		// 从前往后找
 		p = ll.head // 对应索引 -1
@@ -183,3 +467,37 @@ func (ll *LinkedList[T]) getNodeAt(idx int) *node[T] {
 		return p
 	}
 }
+
+// mergeSortNodes 对从 first 开始、长度为 n 的单向链表（只使用 next 指针）进行归并排序，返回排序后的第一个节点
+// 排序后最后一个节点的 next 为 nil
+func mergeSortNodes[T any](first *Element[T], n int, compare genericgo.Comparator[T]) *Element[T] {
+	if n == 1 {
+		first.next = nil
+		return first
+	}
+	// 找到后半部分的第一个节点
+	mid := first
+	for i := 0; i < n/2; i++ {
+		mid = mid.next
+	}
+	left := mergeSortNodes(first, n/2, compare)
+	right := mergeSortNodes(mid, n-n/2, compare)
+
+	// 合并两个有序链表，相等时优先取左边的节点以保证稳定性
+	dummy := &Element[T]{}
+	tail := dummy
+	for left != nil && right != nil {
+		if compare(right.val, left.val) < 0 {
+			tail.next, right = right, right.next
+		} else {
+			tail.next, left = left, left.next
+		}
+		tail = tail.next
+	}
+	if left != nil {
+		tail.next = left
+	} else {
+		tail.next = right
+	}
+	return dummy.next
+}
@@ -10,13 +10,16 @@
 package list
 
 import (
+	"cmp"
 	"fmt"
 	"testing"
 
 	"errors"
 
 	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/iterator"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLinkedList_Append(t *testing.T) {
@@ -505,3 +508,436 @@ func TestLinkedList_AsSlice(t *testing.T) {
 		})
 	}
 }
+
+func TestLinkedList_Iterator(t *testing.T) {
+	tests := []struct {
+		name        string
+		list        *LinkedList[int]
+		wantVals    []int
+		wantReverse []int
+	}{
+		{
+			name:        "Empty list",
+			list:        NewLinkedList[int](),
+			wantVals:    []int{},
+			wantReverse: []int{},
+		},
+		{
+			name:        "Multiple values",
+			list:        NewLinkedListOf[int]([]int{1, 2, 3}),
+			wantVals:    []int{1, 2, 3},
+			wantReverse: []int{3, 2, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantVals, iterator.Collect(tt.list.Iterator()))
+			assert.Equal(t, tt.wantReverse, iterator.Collect(tt.list.ReverseIterator()))
+		})
+	}
+}
+
+func TestLinkedList_Sort(t *testing.T) {
+	tests := []struct {
+		name     string
+		vals     []int
+		wantVals []int
+	}{
+		{
+			name:     "Empty list",
+			vals:     []int{},
+			wantVals: []int{},
+		},
+		{
+			name:     "Single value",
+			vals:     []int{1},
+			wantVals: []int{1},
+		},
+		{
+			name:     "Unsorted values",
+			vals:     []int{5, 3, 9, 1, 3, 7, 2},
+			wantVals: []int{1, 2, 3, 3, 5, 7, 9},
+		},
+		{
+			name:     "Reversed values",
+			vals:     []int{4, 3, 2, 1},
+			wantVals: []int{1, 2, 3, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vals := make([]int, len(tt.vals))
+			copy(vals, tt.vals)
+			l := NewLinkedListOf[int](vals)
+			l.Sort(cmp.Compare[int])
+			assert.Equal(t, tt.wantVals, l.AsSlice())
+
+			copy(vals, tt.vals)
+			l = NewLinkedListOf[int](vals)
+			l.SortStable(cmp.Compare[int])
+			assert.Equal(t, tt.wantVals, l.AsSlice())
+			assert.Equal(t, tt.wantVals, iterator.Collect(l.Iterator()))
+		})
+	}
+}
+
+func TestLinkedList_SortStable(t *testing.T) {
+	type item struct {
+		key   int
+		order int
+	}
+	vals := []item{{2, 0}, {1, 1}, {2, 2}, {1, 3}, {0, 4}, {2, 5}}
+	l := NewLinkedListOf[item](vals)
+	l.SortStable(func(a, b item) int {
+		return cmp.Compare(a.key, b.key)
+	})
+	assert.Equal(t, []item{{0, 4}, {1, 1}, {1, 3}, {2, 0}, {2, 2}, {2, 5}}, l.AsSlice())
+}
+
+func TestLinkedList_SearchSorted(t *testing.T) {
+	tests := []struct {
+		name      string
+		vals      []int
+		target    int
+		wantIdx   int
+		wantFound bool
+	}{
+		{
+			name:    "Empty list",
+			vals:    []int{},
+			target:  1,
+			wantIdx: 0,
+		},
+		{
+			name:      "Found",
+			vals:      []int{1, 3, 5, 7},
+			target:    5,
+			wantIdx:   2,
+			wantFound: true,
+		},
+		{
+			name:    "Not found in middle",
+			vals:    []int{1, 3, 5, 7},
+			target:  4,
+			wantIdx: 2,
+		},
+		{
+			name:    "Greater than all",
+			vals:    []int{1, 3, 5, 7},
+			target:  8,
+			wantIdx: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vals := tt.vals
+			l := NewLinkedListOf[int](vals)
+			idx, found := l.SearchSorted(tt.target, cmp.Compare[int])
+			assert.Equal(t, tt.wantIdx, idx)
+			assert.Equal(t, tt.wantFound, found)
+		})
+	}
+}
+
+func TestLinkedList_RemoveIf(t *testing.T) {
+	tests := []struct {
+		name        string
+		vals        []int
+		match       func(val int) bool
+		wantRemoved int
+		wantSlice   []int
+	}{
+		{
+			name:        "Empty list",
+			vals:        []int{},
+			match:       func(val int) bool { return true },
+			wantRemoved: 0,
+			wantSlice:   []int{},
+		},
+		{
+			name:        "Remove even values",
+			vals:        []int{1, 2, 3, 4, 5, 6},
+			match:       func(val int) bool { return val%2 == 0 },
+			wantRemoved: 3,
+			wantSlice:   []int{1, 3, 5},
+		},
+		{
+			name:        "Remove nothing",
+			vals:        []int{1, 3, 5},
+			match:       func(val int) bool { return val > 10 },
+			wantRemoved: 0,
+			wantSlice:   []int{1, 3, 5},
+		},
+		{
+			name:        "Remove all",
+			vals:        []int{1, 2, 3},
+			match:       func(val int) bool { return true },
+			wantRemoved: 3,
+			wantSlice:   []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLinkedListOf[int](tt.vals)
+			assert.Equal(t, tt.wantRemoved, l.RemoveIf(tt.match))
+			assert.Equal(t, tt.wantSlice, l.AsSlice())
+			assert.Equal(t, len(tt.wantSlice), l.Len())
+		})
+	}
+}
+
+func TestLinkedList_InsertAll(t *testing.T) {
+	tests := []struct {
+		name      string
+		vals      []int
+		idx       int
+		inserted  []int
+		wantSlice []int
+		wantErr   error
+	}{
+		{
+			name:      "Insert into empty list",
+			vals:      []int{},
+			idx:       0,
+			inserted:  []int{1, 2},
+			wantSlice: []int{1, 2},
+		},
+		{
+			name:      "Insert at head",
+			vals:      []int{3, 4},
+			idx:       0,
+			inserted:  []int{1, 2},
+			wantSlice: []int{1, 2, 3, 4},
+		},
+		{
+			name:      "Insert in middle",
+			vals:      []int{1, 4},
+			idx:       1,
+			inserted:  []int{2, 3},
+			wantSlice: []int{1, 2, 3, 4},
+		},
+		{
+			name:      "Insert at tail",
+			vals:      []int{1, 2},
+			idx:       2,
+			inserted:  []int{3, 4},
+			wantSlice: []int{1, 2, 3, 4},
+		},
+		{
+			name:      "Insert nothing",
+			vals:      []int{1, 2},
+			idx:       1,
+			inserted:  nil,
+			wantSlice: []int{1, 2},
+		},
+		{
+			name:      "Negative index",
+			vals:      []int{1, 2},
+			idx:       -1,
+			inserted:  []int{3},
+			wantSlice: []int{1, 2},
+			wantErr:   errs.NewErrIndexOutOfRange(2, -1),
+		},
+		{
+			name:      "Index out of range",
+			vals:      []int{1, 2},
+			idx:       3,
+			inserted:  []int{3},
+			wantSlice: []int{1, 2},
+			wantErr:   errs.NewErrIndexOutOfRange(2, 3),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLinkedListOf[int](tt.vals)
+			err := l.InsertAll(tt.idx, tt.inserted...)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantSlice, l.AsSlice())
+			assert.Equal(t, len(tt.wantSlice), l.Len())
+		})
+	}
+}
+
+func TestLinkedList_Clear(t *testing.T) {
+	l := NewLinkedListOf[int]([]int{1, 2, 3})
+	l.Clear()
+	assert.Equal(t, 0, l.Len())
+	assert.Equal(t, []int{}, l.AsSlice())
+
+	// 清空之后仍然可以正常使用
+	l.Append(4, 5)
+	assert.Equal(t, []int{4, 5}, l.AsSlice())
+}
+
+func TestLinkedList_IndexOf(t *testing.T) {
+	equal := func(left, right int) bool { return left == right }
+	tests := []struct {
+		name      string
+		vals      []int
+		target    int
+		wantIdx   int
+		wantFound bool
+	}{
+		{
+			name:    "Empty list",
+			vals:    []int{},
+			target:  1,
+			wantIdx: -1,
+		},
+		{
+			name:      "First occurrence",
+			vals:      []int{1, 2, 3, 2},
+			target:    2,
+			wantIdx:   1,
+			wantFound: true,
+		},
+		{
+			name:    "Not found",
+			vals:    []int{1, 2, 3},
+			target:  4,
+			wantIdx: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLinkedListOf[int](tt.vals)
+			assert.Equal(t, tt.wantIdx, l.IndexOf(tt.target, equal))
+			assert.Equal(t, tt.wantFound, l.Contains(tt.target, equal))
+		})
+	}
+}
+
+func TestLinkedList_Swap(t *testing.T) {
+	tests := []struct {
+		name      string
+		vals      []int
+		i         int
+		j         int
+		wantSlice []int
+		wantErr   error
+	}{
+		{
+			name:      "Swap head and tail",
+			vals:      []int{1, 2, 3, 4},
+			i:         0,
+			j:         3,
+			wantSlice: []int{4, 2, 3, 1},
+		},
+		{
+			name:      "Swap same index",
+			vals:      []int{1, 2, 3},
+			i:         1,
+			j:         1,
+			wantSlice: []int{1, 2, 3},
+		},
+		{
+			name:      "First index out of range",
+			vals:      []int{1, 2, 3},
+			i:         3,
+			j:         0,
+			wantSlice: []int{1, 2, 3},
+			wantErr:   errs.NewErrIndexOutOfRange(3, 3),
+		},
+		{
+			name:      "Second index out of range",
+			vals:      []int{1, 2, 3},
+			i:         0,
+			j:         -1,
+			wantSlice: []int{1, 2, 3},
+			wantErr:   errs.NewErrIndexOutOfRange(3, -1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLinkedListOf[int](tt.vals)
+			err := l.Swap(tt.i, tt.j)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantSlice, l.AsSlice())
+		})
+	}
+}
+
+func TestLinkedList_Element(t *testing.T) {
+	ll := NewLinkedList[int]()
+	assert.Nil(t, ll.Front())
+	assert.Nil(t, ll.Back())
+
+	e2 := ll.PushBack(2)
+	e1 := ll.PushFront(1)
+	e4 := ll.PushBack(4)
+	e3, err := ll.InsertBefore(3, e4)
+	require.NoError(t, err)
+	e5, err := ll.InsertAfter(5, e4)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, ll.AsSlice())
+	assert.Equal(t, 5, ll.Len())
+
+	// 沿节点句柄遍历
+	assert.Same(t, e1, ll.Front())
+	assert.Same(t, e5, ll.Back())
+	var vals []int
+	for e := ll.Front(); e != nil; e = e.Next() {
+		vals = append(vals, e.Value())
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, vals)
+	vals = vals[:0]
+	for e := ll.Back(); e != nil; e = e.Prev() {
+		vals = append(vals, e.Value())
+	}
+	assert.Equal(t, []int{5, 4, 3, 2, 1}, vals)
+
+	// 移动节点
+	require.NoError(t, ll.MoveToFront(e3))
+	assert.Equal(t, []int{3, 1, 2, 4, 5}, ll.AsSlice())
+	require.NoError(t, ll.MoveToFront(e3))
+	assert.Equal(t, []int{3, 1, 2, 4, 5}, ll.AsSlice())
+	require.NoError(t, ll.MoveToBack(e1))
+	assert.Equal(t, []int{3, 2, 4, 5, 1}, ll.AsSlice())
+	require.NoError(t, ll.MoveToBack(e1))
+	assert.Equal(t, []int{3, 2, 4, 5, 1}, ll.AsSlice())
+
+	// 修改节点中的元素
+	e2.SetValue(20)
+	val, err := ll.Get(1)
+	require.NoError(t, err)
+	assert.Equal(t, 20, val)
+
+	// 删除节点
+	val, err = ll.Remove(e4)
+	require.NoError(t, err)
+	assert.Equal(t, 4, val)
+	assert.Equal(t, []int{3, 20, 5, 1}, ll.AsSlice())
+	assert.Equal(t, 4, ll.Len())
+	assert.Nil(t, e4.Next())
+	assert.Nil(t, e4.Prev())
+
+	// 被删除的节点和其他链表的节点都是无效的
+	_, err = ll.Remove(e4)
+	assert.Equal(t, errs.NewErrInvalidHandle(), err)
+	other := NewLinkedList[int]()
+	foreign := other.PushBack(100)
+	assert.Equal(t, errs.NewErrInvalidHandle(), ll.MoveToFront(foreign))
+	assert.Equal(t, errs.NewErrInvalidHandle(), ll.MoveToBack(nil))
+	_, err = ll.InsertBefore(0, foreign)
+	assert.Equal(t, errs.NewErrInvalidHandle(), err)
+	_, err = ll.InsertAfter(0, e4)
+	assert.Equal(t, errs.NewErrInvalidHandle(), err)
+	assert.Equal(t, []int{100}, other.AsSlice())
+
+	// 按下标删除和 Clear 之后，节点也会失效
+	_, err = ll.Delete(0)
+	require.NoError(t, err)
+	assert.Equal(t, errs.NewErrInvalidHandle(), ll.MoveToBack(e3))
+	ll.Clear()
+	_, err = ll.Remove(e5)
+	assert.Equal(t, errs.NewErrInvalidHandle(), err)
+	assert.Nil(t, ll.Front())
+	assert.Equal(t, 0, ll.Len())
+}
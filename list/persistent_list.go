@@ -0,0 +1,184 @@
+// Package list
+/**
+* @Project : GenericGo
+* @File    : persistent_list.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/6 14:10
+**/
+
+package list
+
+import (
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/iterator"
+)
+
+var (
+	_ iterator.Iterable[any] = &PersistentList[any]{}
+)
+
+// consNode 是 PersistentList 的节点，节点一旦创建就不会再被修改，因此可以被多个版本共享
+type consNode[T any] struct {
+	val  T
+	next *consNode[T]
+}
+
+// PersistentList 不可变的持久化单向链表（cons list）。
+// 所有修改操作都不会改变原来的 PersistentList，而是返回一个新版本，新版本与旧版本共享未被修改的后缀节点。
+// 因为任何版本都不会被修改，所以可以在多个协程之间直接共享而不需要加锁。
+// 在头部插入和删除的时间复杂度为 O(1)，按下标访问和修改的时间复杂度为 O(idx)；
+// 如果需要高效的随机访问和尾部追加，请使用 PersistentVector。
+type PersistentList[T any] struct {
+	head   *consNode[T]
+	length int
+}
+
+// Prepend 返回在头部插入 val 之后的新版本，时间复杂度为 O(1)。
+func (Self *PersistentList[T]) Prepend(val T) *PersistentList[T] {
+	return &PersistentList[T]{
+		head:   &consNode[T]{val: val, next: Self.head},
+		length: Self.length + 1,
+	}
+}
+
+// Tail 返回去掉第一个元素之后的新版本，时间复杂度为 O(1)。
+// 如果 PersistentList 为空，返回 errs.NewErrEmptySlice。
+func (Self *PersistentList[T]) Tail() (*PersistentList[T], error) {
+	if Self.length == 0 {
+		return nil, errs.NewErrEmptySlice()
+	}
+	return &PersistentList[T]{
+		head:   Self.head.next,
+		length: Self.length - 1,
+	}, nil
+}
+
+// Append 返回在末尾追加一个或多个元素之后的新版本。
+// 单向链表的末尾无法共享，需要复制所有节点，时间复杂度为 O(n)。
+func (Self *PersistentList[T]) Append(vals ...T) *PersistentList[T] {
+	if len(vals) == 0 {
+		return Self
+	}
+	var suffix *consNode[T]
+	for i := len(vals) - 1; i >= 0; i-- {
+		suffix = &consNode[T]{val: vals[i], next: suffix}
+	}
+	return Self.replacePrefix(Self.length, suffix, Self.length+len(vals))
+}
+
+// Add 返回在特定下标处增加一个新元素之后的新版本，下标之后的节点会被共享。
+// 如果下标超出合法范围，返回错误。
+// 如果 idx 等于 PersistentList 长度，则表示往 PersistentList 末端增加元素。
+func (Self *PersistentList[T]) Add(idx int, val T) (*PersistentList[T], error) {
+	if idx < 0 || idx > Self.length {
+		return nil, errs.NewErrIndexOutOfRange(Self.length, idx)
+	}
+	suffix := &consNode[T]{val: val, next: Self.nodeAt(idx)}
+	return Self.replacePrefix(idx, suffix, Self.length+1), nil
+}
+
+// Delete 返回删除指定下标的元素之后的新版本，以及被删除的元素，下标之后的节点会被共享。
+// 如果下标超出合法范围，返回错误。
+func (Self *PersistentList[T]) Delete(idx int) (*PersistentList[T], T, error) {
+	if idx < 0 || idx >= Self.length {
+		var zero T
+		return nil, zero, errs.NewErrIndexOutOfRange(Self.length, idx)
+	}
+	target := Self.nodeAt(idx)
+	return Self.replacePrefix(idx, target.next, Self.length-1), target.val, nil
+}
+
+// Set 返回将指定下标位置的元素重置为 val 之后的新版本，下标之后的节点会被共享。
+// 如果下标超出合法范围，返回错误。
+func (Self *PersistentList[T]) Set(idx int, val T) (*PersistentList[T], error) {
+	if idx < 0 || idx >= Self.length {
+		return nil, errs.NewErrIndexOutOfRange(Self.length, idx)
+	}
+	suffix := &consNode[T]{val: val, next: Self.nodeAt(idx).next}
+	return Self.replacePrefix(idx, suffix, Self.length), nil
+}
+
+// Get 返回对应下标的元素。
+// 如果下标超出合法范围，返回错误。
+func (Self *PersistentList[T]) Get(idx int) (val T, err error) {
+	if idx < 0 || idx >= Self.length {
+		return val, errs.NewErrIndexOutOfRange(Self.length, idx)
+	}
+	return Self.nodeAt(idx).val, nil
+}
+
+// Len 返回 PersistentList 中元素的数量。
+func (Self *PersistentList[T]) Len() int {
+	return Self.length
+}
+
+// Range 遍历 PersistentList 的所有元素，并使用给定的函数访问每个元素。
+func (Self *PersistentList[T]) Range(onVal func(idx int, val T) error) error {
+	for p, i := Self.head, 0; p != nil; p, i = p.next, i+1 {
+		if err := onVal(i, p.val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Iterator 返回从前往后遍历 PersistentList 的迭代器。
+// 由于 PersistentList 不可变，迭代器在任何时候都是安全的。
+func (Self *PersistentList[T]) Iterator() iterator.Iterator[T] {
+	p := Self.head
+	return iterator.FromFunc(func() (T, bool) {
+		if p == nil {
+			var zero T
+			return zero, false
+		}
+		val := p.val
+		p = p.next
+		return val, true
+	})
+}
+
+// AsSlice 将 PersistentList 转化为一个新切片，即使 PersistentList 为空，也返回一个长度和容量都为0的切片。
+func (Self *PersistentList[T]) AsSlice() []T {
+	res := make([]T, 0, Self.length)
+	for p := Self.head; p != nil; p = p.next {
+		res = append(res, p.val)
+	}
+	return res
+}
+
+// nodeAt 返回下标为 idx 的节点，idx 等于长度时返回 nil
+// 因为该函数只供内部使用，所以在调用该函数之前已经确保了索引合法
+func (Self *PersistentList[T]) nodeAt(idx int) *consNode[T] {
+	p := Self.head
+	for i := 0; i < idx; i++ {
+		p = p.next
+	}
+	return p
+}
+
+// replacePrefix 复制前 n 个节点，并将复制出的最后一个节点连接到 suffix 上，返回长度为 length 的新版本
+func (Self *PersistentList[T]) replacePrefix(n int, suffix *consNode[T], length int) *PersistentList[T] {
+	dummy := &consNode[T]{}
+	tail := dummy
+	for p, i := Self.head, 0; i < n; p, i = p.next, i+1 {
+		tail.next = &consNode[T]{val: p.val}
+		tail = tail.next
+	}
+	tail.next = suffix
+	return &PersistentList[T]{
+		head:   dummy.next,
+		length: length,
+	}
+}
+
+// NewPersistentList 创建并返回一个空的 PersistentList。
+func NewPersistentList[T any]() *PersistentList[T] {
+	return &PersistentList[T]{}
+}
+
+// NewPersistentListOf 创建一个新的 PersistentList，并按顺序包含 vals 中的元素。
+// 此函数会复制 vals，之后对 vals 的修改不会影响返回的 PersistentList。
+func NewPersistentListOf[T any](vals []T) *PersistentList[T] {
+	return NewPersistentList[T]().Append(vals...)
+}
@@ -0,0 +1,204 @@
+// Package list
+/**
+* @Project : GenericGo
+* @File    : persistent_list_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/6 15:30
+**/
+
+package list
+
+import (
+	"testing"
+
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/iterator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistentList_Prepend(t *testing.T) {
+	empty := NewPersistentList[int]()
+	l1 := empty.Prepend(1)
+	l2 := l1.Prepend(2)
+	l3 := l1.Prepend(3)
+
+	assert.Equal(t, []int{}, empty.AsSlice())
+	assert.Equal(t, []int{1}, l1.AsSlice())
+	assert.Equal(t, []int{2, 1}, l2.AsSlice())
+	assert.Equal(t, []int{3, 1}, l3.AsSlice())
+	// l2 和 l3 共享 l1 的节点
+	assert.Same(t, l1.head, l2.head.next)
+	assert.Same(t, l1.head, l3.head.next)
+}
+
+func TestPersistentList_Tail(t *testing.T) {
+	l := NewPersistentListOf([]int{1, 2, 3})
+	tail, err := l.Tail()
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, tail.AsSlice())
+	assert.Equal(t, 2, tail.Len())
+	assert.Equal(t, []int{1, 2, 3}, l.AsSlice())
+
+	_, err = NewPersistentList[int]().Tail()
+	assert.Equal(t, errs.NewErrEmptySlice(), err)
+}
+
+func TestPersistentList_Append(t *testing.T) {
+	l := NewPersistentListOf([]int{1, 2})
+	appended := l.Append(3, 4)
+	assert.Equal(t, []int{1, 2, 3, 4}, appended.AsSlice())
+	assert.Equal(t, 4, appended.Len())
+	assert.Equal(t, []int{1, 2}, l.AsSlice())
+	assert.Same(t, l, l.Append())
+}
+
+func TestPersistentList_Add(t *testing.T) {
+	tests := []struct {
+		name      string
+		vals      []int
+		idx       int
+		val       int
+		wantSlice []int
+		wantErr   error
+	}{
+		{
+			name:      "Add to empty list",
+			vals:      []int{},
+			idx:       0,
+			val:       1,
+			wantSlice: []int{1},
+		},
+		{
+			name:      "Add at head",
+			vals:      []int{2, 3},
+			idx:       0,
+			val:       1,
+			wantSlice: []int{1, 2, 3},
+		},
+		{
+			name:      "Add in middle",
+			vals:      []int{1, 3},
+			idx:       1,
+			val:       2,
+			wantSlice: []int{1, 2, 3},
+		},
+		{
+			name:      "Add at tail",
+			vals:      []int{1, 2},
+			idx:       2,
+			val:       3,
+			wantSlice: []int{1, 2, 3},
+		},
+		{
+			name:    "Index out of range",
+			vals:    []int{1, 2},
+			idx:     3,
+			wantErr: errs.NewErrIndexOutOfRange(2, 3),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewPersistentListOf(tt.vals)
+			res, err := l.Add(tt.idx, tt.val)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.vals, l.AsSlice())
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.wantSlice, res.AsSlice())
+			assert.Equal(t, len(tt.wantSlice), res.Len())
+		})
+	}
+}
+
+func TestPersistentList_Delete(t *testing.T) {
+	tests := []struct {
+		name      string
+		vals      []int
+		idx       int
+		wantVal   int
+		wantSlice []int
+		wantErr   error
+	}{
+		{
+			name:      "Delete head",
+			vals:      []int{1, 2, 3},
+			idx:       0,
+			wantVal:   1,
+			wantSlice: []int{2, 3},
+		},
+		{
+			name:      "Delete middle",
+			vals:      []int{1, 2, 3},
+			idx:       1,
+			wantVal:   2,
+			wantSlice: []int{1, 3},
+		},
+		{
+			name:      "Delete tail",
+			vals:      []int{1, 2, 3},
+			idx:       2,
+			wantVal:   3,
+			wantSlice: []int{1, 2},
+		},
+		{
+			name:    "Delete from empty list",
+			vals:    []int{},
+			idx:     0,
+			wantErr: errs.NewErrIndexOutOfRange(0, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewPersistentListOf(tt.vals)
+			res, val, err := l.Delete(tt.idx)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.vals, l.AsSlice())
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.wantVal, val)
+			assert.Equal(t, tt.wantSlice, res.AsSlice())
+			assert.Equal(t, len(tt.wantSlice), res.Len())
+		})
+	}
+}
+
+func TestPersistentList_SetGet(t *testing.T) {
+	l := NewPersistentListOf([]int{1, 2, 3})
+	res, err := l.Set(1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 20, 3}, res.AsSlice())
+	assert.Equal(t, []int{1, 2, 3}, l.AsSlice())
+	// 被修改位置之后的节点是共享的
+	assert.Same(t, l.head.next.next, res.head.next.next)
+
+	val, err := res.Get(1)
+	require.NoError(t, err)
+	assert.Equal(t, 20, val)
+
+	_, err = l.Set(3, 0)
+	assert.Equal(t, errs.NewErrIndexOutOfRange(3, 3), err)
+	_, err = l.Get(-1)
+	assert.Equal(t, errs.NewErrIndexOutOfRange(3, -1), err)
+}
+
+func TestPersistentList_RangeAndIterator(t *testing.T) {
+	l := NewPersistentListOf([]int{1, 2, 3})
+	var idxs, vals []int
+	err := l.Range(func(idx int, val int) error {
+		idxs = append(idxs, idx)
+		vals = append(vals, val)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2}, idxs)
+	assert.Equal(t, []int{1, 2, 3}, vals)
+
+	assert.Equal(t, []int{1, 2, 3}, iterator.Collect(l.Iterator()))
+	assert.Equal(t, []int{}, iterator.Collect(NewPersistentList[int]().Iterator()))
+}
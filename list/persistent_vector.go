@@ -0,0 +1,426 @@
+// Package list
+/**
+* @Project : GenericGo
+* @File    : persistent_vector.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/6 14:40
+**/
+
+package list
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/iterator"
+)
+
+var (
+	_ iterator.ReverseIterable[any] = &PersistentVector[any]{}
+)
+
+const (
+	vectorBits     = 5               // 每个节点的分支数使用的二进制位数
+	vectorWidth    = 1 << vectorBits // 每个节点最多拥有的元素或子节点数量，即 32
+	vectorMinWidth = vectorWidth / 2 // 除了最右侧路径上的节点和根节点，每个节点至少拥有的元素或子节点数量
+)
+
+// vectorNode 是 PersistentVector 中 32 叉 B+ 树的节点，内部节点只使用 children 和 sizes，叶子节点只使用 vals
+// 节点一旦被某个版本引用就不会再被修改，修改时总是创建从根节点到目标叶子节点路径上的新节点
+type vectorNode[T any] struct {
+	children []*vectorNode[T]
+	sizes    []int // sizes[i] 为前 i+1 个子节点中元素数量之和，用于按下标查找子节点
+	vals     []T
+}
+
+// count 返回以 node 为根的子树中元素的数量
+func (node *vectorNode[T]) count() int {
+	if node.sizes == nil {
+		return len(node.vals)
+	}
+	return node.sizes[len(node.sizes)-1]
+}
+
+// width 返回节点拥有的元素或子节点的数量
+func (node *vectorNode[T]) width() int {
+	if node.sizes == nil {
+		return len(node.vals)
+	}
+	return len(node.children)
+}
+
+// childIndex 返回子树中第 rel 个元素所在的子节点的下标，以及该子节点中第一个元素在子树中的下标
+func (node *vectorNode[T]) childIndex(rel int) (int, int) {
+	i := sort.Search(len(node.sizes), func(i int) bool {
+		return node.sizes[i] > rel
+	})
+	if i == 0 {
+		return 0, 0
+	}
+	return i, node.sizes[i-1]
+}
+
+// newVectorInternal 创建一个拥有 children 的内部节点，并计算 sizes
+func newVectorInternal[T any](children []*vectorNode[T]) *vectorNode[T] {
+	sizes := make([]int, len(children))
+	total := 0
+	for i, child := range children {
+		total += child.count()
+		sizes[i] = total
+	}
+	return &vectorNode[T]{
+		children: children,
+		sizes:    sizes,
+	}
+}
+
+// PersistentVector 不可变的持久化向量，基于记录了子树大小的 32 叉 B+ 树实现。
+// 所有修改操作都不会改变原来的 PersistentVector，而是返回一个新版本，新版本只重新创建从根节点到被修改的叶子节点的路径，
+// 其余节点与旧版本共享。因为任何版本都不会被修改，所以可以在多个协程之间直接共享而不需要加锁。
+//
+// 每个内部节点都记录了子树的大小，因此删除任意位置的元素之后叶子节点不需要保持满的状态，
+// 只需要在节点过小时与相邻节点合并或者重新分配，Get、Set 和 Delete 的时间复杂度都为 O(log32 n)。
+// 最后不超过 32 个的元素保存在单独的 tail 中，因此 Append 和 Pop 的均摊时间复杂度接近 O(1)。
+type PersistentVector[T any] struct {
+	root   *vectorNode[T] // 前缀部分的 B+ 树，所有元素都在 tail 中时为 nil
+	tail   []T            // 最后不超过 32 个元素，length 大于 0 时不为空
+	height int            // B+ 树中内部节点的层数，root 为叶子节点时为 0
+	length int
+}
+
+// Append 返回在末尾追加一个或多个元素之后的新版本，每个元素的时间复杂度为 O(log32 n)。
+func (Self *PersistentVector[T]) Append(vals ...T) *PersistentVector[T] {
+	if len(vals) == 0 {
+		return Self
+	}
+	res := *Self
+	tail := make([]T, len(Self.tail), vectorWidth)
+	copy(tail, Self.tail)
+	for _, val := range vals {
+		if len(tail) == vectorWidth {
+			// tail 已满，将其作为叶子节点放入 B+ 树，新的 tail 只需要在本次调用内复制
+			res.pushTail(&vectorNode[T]{vals: tail})
+			tail = make([]T, 0, vectorWidth)
+		}
+		tail = append(tail, val)
+		res.length++
+	}
+	res.tail = tail
+	return &res
+}
+
+// Pop 返回删除最后一个元素之后的新版本，以及被删除的元素，等同于 Delete(Len()-1)。
+// 如果 PersistentVector 为空，返回 errs.NewErrEmptySlice。
+func (Self *PersistentVector[T]) Pop() (*PersistentVector[T], T, error) {
+	if Self.length == 0 {
+		var zero T
+		return nil, zero, errs.NewErrEmptySlice()
+	}
+	return Self.Delete(Self.length - 1)
+}
+
+// Delete 返回删除指定下标的元素之后的新版本，以及被删除的元素，时间复杂度为 O(log32 n)。
+// 如果下标超出合法范围，返回错误。
+func (Self *PersistentVector[T]) Delete(idx int) (*PersistentVector[T], T, error) {
+	if idx < 0 || idx >= Self.length {
+		var zero T
+		return nil, zero, errs.NewErrIndexOutOfRange(Self.length, idx)
+	}
+	res := *Self
+	res.length--
+	if offset := Self.tailOffset(); idx >= offset {
+		i := idx - offset
+		val := Self.tail[i]
+		if i == len(Self.tail)-1 {
+			// 新版本只读取 tail 的前缀，之后任何版本修改 tail 之前都会先复制，因此可以共享底层数组
+			res.tail = Self.tail[:i]
+		} else {
+			res.tail = slices.Concat(Self.tail[:i], Self.tail[i+1:])
+		}
+		if len(res.tail) == 0 && res.root != nil {
+			// tail 为空时，将 B+ 树中最后一个叶子节点取出作为新的 tail
+			res.tail = res.leafFor(res.length - 1).vals
+			res.root = popLastLeaf(res.root, res.height)
+			res.shrink()
+		}
+		return &res, val, nil
+	}
+
+	var val T
+	res.root, val = removeAt(Self.root, Self.height, idx)
+	res.shrink()
+	return &res, val, nil
+}
+
+// Set 返回将指定下标位置的元素重置为 val 之后的新版本，时间复杂度为 O(log32 n)。
+// 如果下标超出合法范围，返回错误。
+func (Self *PersistentVector[T]) Set(idx int, val T) (*PersistentVector[T], error) {
+	if idx < 0 || idx >= Self.length {
+		return nil, errs.NewErrIndexOutOfRange(Self.length, idx)
+	}
+	res := *Self
+	if offset := Self.tailOffset(); idx >= offset {
+		res.tail = make([]T, len(Self.tail), vectorWidth)
+		copy(res.tail, Self.tail)
+		res.tail[idx-offset] = val
+		return &res, nil
+	}
+	res.root = setAt(Self.root, Self.height, idx, val)
+	return &res, nil
+}
+
+// Get 返回对应下标的元素，时间复杂度为 O(log32 n)。
+// 如果下标超出合法范围，返回错误。
+func (Self *PersistentVector[T]) Get(idx int) (val T, err error) {
+	if idx < 0 || idx >= Self.length {
+		return val, errs.NewErrIndexOutOfRange(Self.length, idx)
+	}
+	leaf, start := Self.leafValsFor(idx)
+	return leaf[idx-start], nil
+}
+
+// Len 返回 PersistentVector 中元素的数量。
+func (Self *PersistentVector[T]) Len() int {
+	return Self.length
+}
+
+// Range 遍历 PersistentVector 的所有元素，并使用给定的函数访问每个元素。
+func (Self *PersistentVector[T]) Range(onVal func(idx int, val T) error) error {
+	for i := 0; i < Self.length; {
+		leaf, _ := Self.leafValsFor(i)
+		for _, val := range leaf {
+			if err := onVal(i, val); err != nil {
+				return err
+			}
+			i++
+		}
+	}
+	return nil
+}
+
+// Iterator 返回从前往后遍历 PersistentVector 的迭代器，每次只在进入新的叶子节点时查找一次 B+ 树。
+// 由于 PersistentVector 不可变，迭代器在任何时候都是安全的。
+func (Self *PersistentVector[T]) Iterator() iterator.Iterator[T] {
+	i, start := 0, 0
+	var leaf []T
+	return iterator.FromFunc(func() (T, bool) {
+		if i >= Self.length {
+			var zero T
+			return zero, false
+		}
+		if i >= start+len(leaf) {
+			leaf, start = Self.leafValsFor(i)
+		}
+		val := leaf[i-start]
+		i++
+		return val, true
+	})
+}
+
+// ReverseIterator 返回从后往前遍历 PersistentVector 的迭代器。
+func (Self *PersistentVector[T]) ReverseIterator() iterator.Iterator[T] {
+	i, start := Self.length-1, Self.length
+	var leaf []T
+	return iterator.FromFunc(func() (T, bool) {
+		if i < 0 {
+			var zero T
+			return zero, false
+		}
+		if i < start {
+			leaf, start = Self.leafValsFor(i)
+		}
+		val := leaf[i-start]
+		i--
+		return val, true
+	})
+}
+
+// AsSlice 将 PersistentVector 转化为一个新切片，即使 PersistentVector 为空，也返回一个长度和容量都为0的切片。
+func (Self *PersistentVector[T]) AsSlice() []T {
+	res := make([]T, 0, Self.length)
+	for len(res) < Self.length {
+		leaf, _ := Self.leafValsFor(len(res))
+		res = append(res, leaf...)
+	}
+	return res
+}
+
+// tailOffset 返回 tail 中第一个元素的下标
+func (Self *PersistentVector[T]) tailOffset() int {
+	return Self.length - len(Self.tail)
+}
+
+// leafValsFor 返回下标 idx 所在的叶子节点的元素，以及其中第一个元素的下标，idx 在 tail 中时返回 tail
+// 因为该函数只供内部使用，所以在调用该函数之前已经确保了索引合法
+func (Self *PersistentVector[T]) leafValsFor(idx int) ([]T, int) {
+	if offset := Self.tailOffset(); idx >= offset {
+		return Self.tail, offset
+	}
+	node, start := Self.root, 0
+	for level := Self.height; level > 0; level-- {
+		i, childStart := node.childIndex(idx - start)
+		node, start = node.children[i], start+childStart
+	}
+	return node.vals, start
+}
+
+// leafFor 返回 B+ 树中下标 idx 所在的叶子节点
+func (Self *PersistentVector[T]) leafFor(idx int) *vectorNode[T] {
+	node := Self.root
+	for level := Self.height; level > 0; level-- {
+		i, childStart := node.childIndex(idx)
+		node, idx = node.children[i], idx-childStart
+	}
+	return node
+}
+
+// pushTail 将已满的 tail 作为叶子节点放入 B+ 树的最右侧
+func (Self *PersistentVector[T]) pushTail(leaf *vectorNode[T]) {
+	if Self.root == nil {
+		Self.root, Self.height = leaf, 0
+		return
+	}
+	root, overflow := pushLeaf(Self.root, Self.height, leaf)
+	if overflow != nil {
+		// 根节点已满，增加一层
+		root = newVectorInternal([]*vectorNode[T]{root, overflow})
+		Self.height++
+	}
+	Self.root = root
+}
+
+// shrink 在删除之后降低树的高度，直到根节点为叶子节点或者拥有多个子节点
+func (Self *PersistentVector[T]) shrink() {
+	for Self.root != nil && Self.height > 0 && len(Self.root.children) == 1 {
+		Self.root = Self.root.children[0]
+		Self.height--
+	}
+	if Self.root == nil {
+		Self.height = 0
+	}
+}
+
+// pushLeaf 在以 node 为根、高度为 level 的子树的最右侧放入 leaf，并返回新的子树。
+// 如果子树已满，返回的第二个节点是需要放在子树右侧的新兄弟节点。
+func pushLeaf[T any](node *vectorNode[T], level int, leaf *vectorNode[T]) (*vectorNode[T], *vectorNode[T]) {
+	if level == 0 {
+		return node, leaf
+	}
+	last := len(node.children) - 1
+	child, overflow := pushLeaf(node.children[last], level-1, leaf)
+	children := make([]*vectorNode[T], len(node.children), vectorWidth)
+	copy(children, node.children)
+	children[last] = child
+	if overflow == nil {
+		return newVectorInternal(children), nil
+	}
+	if len(children) < vectorWidth {
+		return newVectorInternal(append(children, overflow)), nil
+	}
+	return newVectorInternal(children), newVectorInternal([]*vectorNode[T]{overflow})
+}
+
+// popLastLeaf 移除以 node 为根、高度为 level 的子树中最右侧的叶子节点，如果子树因此变为空，返回 nil
+func popLastLeaf[T any](node *vectorNode[T], level int) *vectorNode[T] {
+	if level == 0 {
+		return nil
+	}
+	last := len(node.children) - 1
+	child := popLastLeaf(node.children[last], level-1)
+	if child == nil {
+		if last == 0 {
+			return nil
+		}
+		return newVectorInternal(slices.Clone(node.children[:last]))
+	}
+	children := slices.Clone(node.children)
+	children[last] = child
+	return newVectorInternal(children)
+}
+
+// removeAt 删除以 node 为根、高度为 level 的子树中第 idx 个元素，返回新的子树以及被删除的元素。
+// 子节点过小时与相邻节点合并或者重新分配，如果子树因此变为空，返回 nil。
+func removeAt[T any](node *vectorNode[T], level int, idx int) (*vectorNode[T], T) {
+	if level == 0 {
+		val := node.vals[idx]
+		if len(node.vals) == 1 {
+			return nil, val
+		}
+		return &vectorNode[T]{vals: slices.Concat(node.vals[:idx], node.vals[idx+1:])}, val
+	}
+	i, childStart := node.childIndex(idx)
+	child, val := removeAt(node.children[i], level-1, idx-childStart)
+	children := slices.Clone(node.children)
+	switch {
+	case child == nil:
+		if len(children) == 1 {
+			return nil, val
+		}
+		children = slices.Delete(children, i, i+1)
+	case child.width() < vectorMinWidth && len(children) > 1:
+		children[i] = child
+		children = rebalance(children, i, level-1)
+	default:
+		children[i] = child
+	}
+	return newVectorInternal(children), val
+}
+
+// rebalance 将过小的子节点 children[i] 与相邻的兄弟节点合并，合并之后超过 32 个时平均分配到两个节点中
+func rebalance[T any](children []*vectorNode[T], i int, level int) []*vectorNode[T] {
+	left := i
+	if i == len(children)-1 {
+		left = i - 1
+	}
+	l, r := children[left], children[left+1]
+	if level == 0 {
+		vals := slices.Concat(l.vals, r.vals)
+		if len(vals) <= vectorWidth {
+			children[left] = &vectorNode[T]{vals: vals}
+			return slices.Delete(children, left+1, left+2)
+		}
+		mid := len(vals) / 2
+		children[left] = &vectorNode[T]{vals: vals[:mid:mid]}
+		children[left+1] = &vectorNode[T]{vals: vals[mid:]}
+		return children
+	}
+	grandChildren := slices.Concat(l.children, r.children)
+	if len(grandChildren) <= vectorWidth {
+		children[left] = newVectorInternal(grandChildren)
+		return slices.Delete(children, left+1, left+2)
+	}
+	mid := len(grandChildren) / 2
+	children[left] = newVectorInternal(grandChildren[:mid:mid])
+	children[left+1] = newVectorInternal(grandChildren[mid:])
+	return children
+}
+
+// setAt 创建从 node 到第 idx 个元素所在叶子节点的新路径，并在新的叶子节点中设置新值
+func setAt[T any](node *vectorNode[T], level int, idx int, val T) *vectorNode[T] {
+	if level == 0 {
+		vals := slices.Clone(node.vals)
+		vals[idx] = val
+		return &vectorNode[T]{vals: vals}
+	}
+	i, childStart := node.childIndex(idx)
+	children := slices.Clone(node.children)
+	children[i] = setAt(node.children[i], level-1, idx-childStart, val)
+	// 元素数量没有变化，sizes 可以与原节点共享
+	return &vectorNode[T]{
+		children: children,
+		sizes:    node.sizes,
+	}
+}
+
+// NewPersistentVector 创建并返回一个空的 PersistentVector。
+func NewPersistentVector[T any]() *PersistentVector[T] {
+	return &PersistentVector[T]{}
+}
+
+// NewPersistentVectorOf 创建一个新的 PersistentVector，并按顺序包含 vals 中的元素。
+// 此函数会复制 vals，之后对 vals 的修改不会影响返回的 PersistentVector。
+func NewPersistentVectorOf[T any](vals []T) *PersistentVector[T] {
+	return NewPersistentVector[T]().Append(vals...)
+}
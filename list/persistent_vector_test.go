@@ -0,0 +1,345 @@
+// Package list
+/**
+* @Project : GenericGo
+* @File    : persistent_vector_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/6 15:50
+**/
+
+package list
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/iterator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistentVector_Append(t *testing.T) {
+	// 覆盖只有 tail、一层、两层和三层前缀树的情况
+	sizes := []int{0, 1, 31, 32, 33, 64, 1024, 1056, 1057, 33 * 1024, 33*1024 + 33}
+	for _, size := range sizes {
+		vals := makeRange(0, size)
+		pv := NewPersistentVectorOf(vals)
+		assert.Equal(t, size, pv.Len())
+		assert.Equal(t, vals, pv.AsSlice())
+
+		// 逐个追加与批量追加的结果一致
+		one := NewPersistentVector[int]()
+		for _, val := range vals {
+			one = one.Append(val)
+		}
+		assert.Equal(t, vals, one.AsSlice())
+
+		for _, idx := range []int{0, size / 2, size - 1} {
+			if idx < 0 || idx >= size {
+				continue
+			}
+			val, err := pv.Get(idx)
+			require.NoError(t, err)
+			assert.Equal(t, idx, val)
+		}
+	}
+}
+
+func TestPersistentVector_Persistence(t *testing.T) {
+	v1 := NewPersistentVectorOf(makeRange(0, 100))
+	v2 := v1.Append(100)
+	v3, err := v1.Set(10, -10)
+	require.NoError(t, err)
+	v4, err := v1.Set(99, -99)
+	require.NoError(t, err)
+	v5, last, err := v1.Pop()
+	require.NoError(t, err)
+	v6 := v5.Append(-1)
+
+	assert.Equal(t, makeRange(0, 100), v1.AsSlice())
+	assert.Equal(t, makeRange(0, 101), v2.AsSlice())
+	assert.Equal(t, 99, last)
+	assert.Equal(t, makeRange(0, 99), v5.AsSlice())
+	assert.Equal(t, append(makeRange(0, 99), -1), v6.AsSlice())
+
+	want := makeRange(0, 100)
+	want[10] = -10
+	assert.Equal(t, want, v3.AsSlice())
+	want = makeRange(0, 100)
+	want[99] = -99
+	assert.Equal(t, want, v4.AsSlice())
+
+	// 修改前缀树中的元素只会复制一条路径，其余叶子节点是共享的
+	assert.Same(t, v1.root.children[1], v3.root.children[1])
+	assert.NotSame(t, v1.root.children[0], v3.root.children[0])
+}
+
+func TestPersistentVector_PopEmpty(t *testing.T) {
+	pv := NewPersistentVector[int]()
+	next, val, err := pv.Pop()
+	assert.Equal(t, errs.NewErrEmptySlice(), err)
+	assert.Nil(t, next)
+	assert.Equal(t, 0, val)
+	assert.Equal(t, 0, pv.Len())
+}
+
+func TestPersistentVector_Pop(t *testing.T) {
+	size := 33*1024 + 33
+	pv := NewPersistentVectorOf(makeRange(0, size))
+	for i := size - 1; i >= 0; i-- {
+		var val int
+		var err error
+		pv, val, err = pv.Pop()
+		require.NoError(t, err)
+		require.Equal(t, i, val)
+		require.Equal(t, i, pv.Len())
+		if i%1000 == 0 || i < 70 {
+			require.Equal(t, makeRange(0, i), pv.AsSlice())
+		}
+	}
+	assert.Nil(t, pv.root)
+	assert.Equal(t, 0, pv.height)
+
+	_, _, err := pv.Pop()
+	assert.Equal(t, errs.NewErrEmptySlice(), err)
+
+	// 清空之后仍然可以正常使用
+	pv = pv.Append(makeRange(0, 40)...)
+	assert.Equal(t, makeRange(0, 40), pv.AsSlice())
+}
+
+func TestPersistentVector_Delete(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      int
+		idx       int
+		wantVal   int
+		wantSlice []int
+		wantErr   error
+	}{
+		{
+			name:      "Delete last",
+			size:      40,
+			idx:       39,
+			wantVal:   39,
+			wantSlice: makeRange(0, 39),
+		},
+		{
+			name:      "Delete first",
+			size:      40,
+			idx:       0,
+			wantVal:   0,
+			wantSlice: makeRange(1, 40),
+		},
+		{
+			name:      "Delete middle",
+			size:      40,
+			idx:       20,
+			wantVal:   20,
+			wantSlice: append(makeRange(0, 20), makeRange(21, 40)...),
+		},
+		{
+			name:      "Delete in tree with multiple levels",
+			size:      3000,
+			idx:       1234,
+			wantVal:   1234,
+			wantSlice: append(makeRange(0, 1234), makeRange(1235, 3000)...),
+		},
+		{
+			name:      "Delete the only element in tail",
+			size:      33,
+			idx:       32,
+			wantVal:   32,
+			wantSlice: makeRange(0, 32),
+		},
+		{
+			name:    "Index out of range",
+			size:    40,
+			idx:     40,
+			wantErr: errs.NewErrIndexOutOfRange(40, 40),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pv := NewPersistentVectorOf(makeRange(0, tt.size))
+			res, val, err := pv.Delete(tt.idx)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, makeRange(0, tt.size), pv.AsSlice())
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.wantVal, val)
+			assert.Equal(t, tt.wantSlice, res.AsSlice())
+			checkPersistentVector(t, res)
+		})
+	}
+}
+
+func TestPersistentVector_DeleteSharing(t *testing.T) {
+	v1 := NewPersistentVectorOf(makeRange(0, 2000))
+	require.Equal(t, 2, v1.height)
+	v2, val, err := v1.Delete(5)
+	require.NoError(t, err)
+	assert.Equal(t, 5, val)
+	assert.Equal(t, append(makeRange(0, 5), makeRange(6, 2000)...), v2.AsSlice())
+	assert.Equal(t, makeRange(0, 2000), v1.AsSlice())
+
+	// 删除前缀树中的元素只会重新创建一条路径，其余节点是共享的
+	assert.Same(t, v1.root.children[1], v2.root.children[1])
+	assert.Same(t, v1.root.children[0].children[1], v2.root.children[0].children[1])
+	assert.NotSame(t, v1.root.children[0].children[0], v2.root.children[0].children[0])
+
+	// 反复删除头部的元素，树的高度会随着元素数量的减少而降低
+	for v2.Len() > 100 {
+		v2, _, err = v2.Delete(0)
+		require.NoError(t, err)
+	}
+	checkPersistentVector(t, v2)
+	assert.Equal(t, makeRange(1900, 2000), v2.AsSlice())
+	assert.LessOrEqual(t, v2.height, 1)
+}
+
+func TestPersistentVector_SetGet_OutOfRange(t *testing.T) {
+	pv := NewPersistentVectorOf([]int{1, 2, 3})
+	_, err := pv.Get(3)
+	assert.Equal(t, errs.NewErrIndexOutOfRange(3, 3), err)
+	_, err = pv.Set(-1, 0)
+	assert.Equal(t, errs.NewErrIndexOutOfRange(3, -1), err)
+}
+
+func TestPersistentVector_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	pv := NewPersistentVector[int]()
+	var model []int
+	for i := 0; i < 20000; i++ {
+		switch op := r.Intn(10); {
+		case op < 6:
+			val := r.Int()
+			pv = pv.Append(val)
+			model = append(model, val)
+		case op < 8 && len(model) > 0:
+			idx, val := r.Intn(len(model)), r.Int()
+			next, err := pv.Set(idx, val)
+			require.NoError(t, err)
+			pv = next
+			model = slices.Clone(model)
+			model[idx] = val
+		case op < 9 && len(model) > 0:
+			next, val, err := pv.Pop()
+			require.NoError(t, err)
+			require.Equal(t, model[len(model)-1], val)
+			pv = next
+			model = model[:len(model)-1]
+		case len(model) > 0:
+			idx := r.Intn(len(model))
+			next, val, err := pv.Delete(idx)
+			require.NoError(t, err)
+			require.Equal(t, model[idx], val)
+			pv = next
+			model = slices.Delete(slices.Clone(model), idx, idx+1)
+		}
+		require.Equal(t, len(model), pv.Len())
+		if i%100 == 0 {
+			checkPersistentVector(t, pv)
+			require.Equal(t, model, pv.AsSlice())
+		}
+	}
+	assert.Equal(t, model, pv.AsSlice())
+	assert.Equal(t, model, iterator.Collect(pv.Iterator()))
+	reversed := slices.Clone(model)
+	slices.Reverse(reversed)
+	assert.Equal(t, reversed, iterator.Collect(pv.ReverseIterator()))
+}
+
+func TestPersistentVector_Range(t *testing.T) {
+	pv := NewPersistentVectorOf(makeRange(0, 70))
+	var idxs, vals []int
+	err := pv.Range(func(idx int, val int) error {
+		idxs = append(idxs, idx)
+		vals = append(vals, val)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, makeRange(0, 70), idxs)
+	assert.Equal(t, makeRange(0, 70), vals)
+
+	assert.Equal(t, []int{}, iterator.Collect(NewPersistentVector[int]().Iterator()))
+	assert.Equal(t, []int{}, iterator.Collect(NewPersistentVector[int]().ReverseIterator()))
+}
+
+func TestPersistentVector_ConcurrentReaders(t *testing.T) {
+	snapshot := NewPersistentVectorOf(makeRange(0, 2000))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// 每个协程基于同一个快照派生自己的版本，快照本身不会被修改
+			local := snapshot
+			for j := 0; j < 500; j++ {
+				local, _ = local.Set(j, -i)
+				local = local.Append(i)
+			}
+			assert.Equal(t, 2500, local.Len())
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, makeRange(0, 2000), snapshot.AsSlice())
+}
+
+// checkPersistentVector 检查 PersistentVector 的结构：
+//  1. 所有叶子节点的深度相同，每个节点都不为空且最多拥有 32 个元素或子节点；
+//  2. 内部节点记录的子树大小与实际的元素数量一致；
+//  3. 元素不为空时 tail 不为空，树的高度与元素数量相称。
+func checkPersistentVector[T any](t *testing.T, pv *PersistentVector[T]) {
+	t.Helper()
+	require.Equal(t, pv.length > 0, len(pv.tail) > 0)
+	require.LessOrEqual(t, len(pv.tail), vectorWidth)
+	count := 0
+	if pv.root != nil {
+		count = checkVectorNode(t, pv.root, pv.height)
+		if pv.height > 0 {
+			require.Greater(t, len(pv.root.children), 1)
+		}
+	} else {
+		require.Equal(t, 0, pv.height)
+	}
+	require.Equal(t, pv.length, count+len(pv.tail))
+	// 除了根节点和最右侧路径，节点至少拥有 vectorMinWidth 个元素或子节点
+	minCount := 1
+	for level := 1; level < pv.height; level++ {
+		minCount *= vectorMinWidth
+	}
+	if pv.root != nil {
+		require.GreaterOrEqual(t, count, minCount)
+	}
+}
+
+func checkVectorNode[T any](t *testing.T, node *vectorNode[T], level int) int {
+	require.Positive(t, node.width())
+	require.LessOrEqual(t, node.width(), vectorWidth)
+	if level == 0 {
+		require.Nil(t, node.children)
+		return len(node.vals)
+	}
+	require.Len(t, node.sizes, len(node.children))
+	total := 0
+	for i, child := range node.children {
+		total += checkVectorNode(t, child, level-1)
+		require.Equal(t, total, node.sizes[i])
+	}
+	return total
+}
+
+// makeRange 返回 [start, end) 之间的所有整数
+func makeRange(start, end int) []int {
+	res := make([]int, 0, end-start)
+	for i := start; i < end; i++ {
+		res = append(res, i)
+	}
+	return res
+}
@@ -0,0 +1,224 @@
+// Package list
+/**
+* @Project : GenericGo
+* @File    : skip_list.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/20 10:16
+**/
+
+package list
+
+import (
+	"math/rand"
+
+	"github.com/HJH0924/GenericGo/errs"
+)
+
+var (
+	_ List[any] = &SkipList[any]{}
+)
+
+const (
+	skipListMaxLevel = 32   // 跳表的最大层数，足以容纳 4^32 个元素
+	skipListP        = 0.25 // 节点拥有更高一层的概率，与 Redis 保持一致
+)
+
+// randomLevel 随机生成新节点的层数，层数为 k 的概率为 (1-p) * p^(k-1)
+func randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && rand.Float64() < skipListP {
+		level++
+	}
+	return level
+}
+
+// skipListLevel 是跳表节点在某一层的索引
+// span 表示从当前节点沿这一层走到 next 跨过的元素个数，next 为 nil 时表示到链表末尾跨过的元素个数
+type skipListLevel[T any] struct {
+	next *skipListNode[T]
+	span int
+}
+
+// skipListNode 是跳表的节点
+type skipListNode[T any] struct {
+	val    T
+	levels []skipListLevel[T]
+}
+
+// SkipList 基于跳表实现的 List，元素按照下标排列，
+// 每一层索引都记录了跨度（span），因此按下标的 Add、Delete、Get 和 Set 的平均时间复杂度都是 O(log n)。
+type SkipList[T any] struct {
+	header *skipListNode[T] // 头节点，不存储元素，拥有最大层数的索引
+	level  int              // 当前跳表的最高层数
+	length int
+}
+
+// Append 在 SkipList 末尾追加一个或多个元素。
+func (sl *SkipList[T]) Append(vals ...T) {
+	for _, val := range vals {
+		sl.insert(sl.length, val)
+	}
+}
+
+// Add 在特定下标处增加一个新元素。
+// 如果下标超出合法范围，返回错误。
+// 如果 idx 等于 SkipList 长度，则表示往 SkipList 末端增加元素。
+func (sl *SkipList[T]) Add(idx int, val T) error {
+	if idx < 0 || idx > sl.length {
+		return errs.NewErrIndexOutOfRange(sl.length, idx)
+	}
+	sl.insert(idx, val)
+	return nil
+}
+
+// Delete 删除指定下标的元素，并返回被删除的元素。
+// 如果下标超出合法范围，返回错误。
+func (sl *SkipList[T]) Delete(idx int) (deletedVal T, err error) {
+	if idx < 0 || idx >= sl.length {
+		return deletedVal, errs.NewErrIndexOutOfRange(sl.length, idx)
+	}
+
+	update, _ := sl.findPrev(idx)
+	target := update[0].levels[0].next
+	for i := 0; i < sl.level; i++ {
+		if update[i].levels[i].next == target {
+			update[i].levels[i].span += target.levels[i].span - 1
+			update[i].levels[i].next = target.levels[i].next
+		} else {
+			update[i].levels[i].span--
+		}
+	}
+	for sl.level > 1 && sl.header.levels[sl.level-1].next == nil {
+		sl.level--
+	}
+	sl.length--
+	return target.val, nil
+}
+
+// Set 重置指定下标位置的元素为 val。
+// 如果下标超出合法范围，返回错误。
+func (sl *SkipList[T]) Set(idx int, val T) error {
+	if idx < 0 || idx >= sl.length {
+		return errs.NewErrIndexOutOfRange(sl.length, idx)
+	}
+	sl.getNodeAt(idx).val = val
+	return nil
+}
+
+// Get 返回对应下标的元素。
+// 如果下标超出合法范围，返回错误。
+func (sl *SkipList[T]) Get(idx int) (val T, err error) {
+	if idx < 0 || idx >= sl.length {
+		return val, errs.NewErrIndexOutOfRange(sl.length, idx)
+	}
+	return sl.getNodeAt(idx).val, nil
+}
+
+// Len 返回 SkipList 中元素的数量。
+func (sl *SkipList[T]) Len() int {
+	return sl.length
+}
+
+// Cap 返回 SkipList 的容量。
+// 与链表一样，跳表的容量等于其长度。
+func (sl *SkipList[T]) Cap() int {
+	return sl.Len()
+}
+
+// Range 遍历 SkipList 的所有元素，并使用给定的函数访问每个元素。
+func (sl *SkipList[T]) Range(onVal func(idx int, val T) error) error {
+	for p, i := sl.header.levels[0].next, 0; p != nil; p, i = p.levels[0].next, i+1 {
+		err := onVal(i, p.val)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AsSlice 将 SkipList 转化为一个新切片，即使 SkipList 为空，也返回一个长度和容量都为0的切片。
+func (sl *SkipList[T]) AsSlice() []T {
+	res := make([]T, 0, sl.length)
+	for p := sl.header.levels[0].next; p != nil; p = p.levels[0].next {
+		res = append(res, p.val)
+	}
+	return res
+}
+
+// insert 在下标 idx 处插入新元素，调用方需要保证 idx 合法
+func (sl *SkipList[T]) insert(idx int, val T) {
+	update, rank := sl.findPrev(idx)
+
+	level := randomLevel()
+	if level > sl.level {
+		for i := sl.level; i < level; i++ {
+			rank[i] = 0
+			update[i] = sl.header
+			update[i].levels[i].span = sl.length
+		}
+		sl.level = level
+	}
+
+	newNode := &skipListNode[T]{
+		val:    val,
+		levels: make([]skipListLevel[T], level),
+	}
+	for i := 0; i < level; i++ {
+		// update[i] 的下标为 rank[i]-1，新节点的下标为 idx，二者之间相隔 idx-rank[i] 个元素
+		newNode.levels[i].next = update[i].levels[i].next
+		newNode.levels[i].span = update[i].levels[i].span - (idx - rank[i])
+		update[i].levels[i].next = newNode
+		update[i].levels[i].span = idx - rank[i] + 1
+	}
+	// 更高层的索引跨过了新节点，跨度加一
+	for i := level; i < sl.level; i++ {
+		update[i].levels[i].span++
+	}
+	sl.length++
+}
+
+// findPrev 返回每一层中下标为 idx 的位置之前的最后一个节点，以及这些节点的排名
+// 排名从1开始，头节点的排名为0，因此节点的下标等于排名减一
+func (sl *SkipList[T]) findPrev(idx int) ([skipListMaxLevel]*skipListNode[T], [skipListMaxLevel]int) {
+	var (
+		update [skipListMaxLevel]*skipListNode[T]
+		rank   [skipListMaxLevel]int
+	)
+	p := sl.header
+	for i := sl.level - 1; i >= 0; i-- {
+		if i < sl.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for p.levels[i].next != nil && rank[i]+p.levels[i].span <= idx {
+			rank[i] += p.levels[i].span
+			p = p.levels[i].next
+		}
+		update[i] = p
+	}
+	return update, rank
+}
+
+// getNodeAt 返回下标为 idx 的节点
+// 因为该函数只供内部使用，所以在调用该函数之前已经确保了索引合法
+func (sl *SkipList[T]) getNodeAt(idx int) *skipListNode[T] {
+	update, _ := sl.findPrev(idx)
+	return update[0].levels[0].next
+}
+
+// NewSkipList 创建并返回一个新的SkipList实例。
+func NewSkipList[T any]() *SkipList[T] {
+	return &SkipList[T]{
+		header: &skipListNode[T]{
+			levels: make([]skipListLevel[T], skipListMaxLevel),
+		},
+		level: 1,
+	}
+}
+
+// NewSkipListOf 创建一个新的SkipList实例，并使用提供的切片vals作为初始元素。
+func NewSkipListOf[T any](vals []T) *SkipList[T] {
+	sl := NewSkipList[T]()
+	sl.Append(vals...)
+	return sl
+}
@@ -0,0 +1,207 @@
+// Package list
+/**
+* @Project : GenericGo
+* @File    : skip_list_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/20 14:30
+**/
+
+package list
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkipList_Add(t *testing.T) {
+	tests := []struct {
+		name      string
+		list      *SkipList[int]
+		index     int
+		value     int
+		wantSlice []int
+		wantErr   error
+	}{
+		{
+			name:      "Add at index 0",
+			list:      NewSkipListOf([]int{235, 346}),
+			index:     0,
+			value:     478,
+			wantSlice: []int{478, 235, 346},
+		},
+		{
+			name:      "Add at index middle",
+			list:      NewSkipListOf([]int{478, 235, 346}),
+			index:     1,
+			value:     867,
+			wantSlice: []int{478, 867, 235, 346},
+		},
+		{
+			name:      "Add at last index",
+			list:      NewSkipListOf([]int{478, 867, 235, 346}),
+			index:     4,
+			value:     345,
+			wantSlice: []int{478, 867, 235, 346, 345},
+		},
+		{
+			name:      "Add to empty list",
+			list:      NewSkipList[int](),
+			index:     0,
+			value:     1,
+			wantSlice: []int{1},
+		},
+		{
+			name:    "Add at out of range index",
+			list:    NewSkipListOf([]int{235, 346, 345}),
+			index:   4,
+			value:   678,
+			wantErr: errs.NewErrIndexOutOfRange(3, 4),
+		},
+		{
+			name:    "Add at negative index",
+			list:    NewSkipListOf([]int{235, 346, 345}),
+			index:   -1,
+			value:   678,
+			wantErr: errs.NewErrIndexOutOfRange(3, -1),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotErr := test.list.Add(test.index, test.value)
+			if gotErr != nil {
+				assert.Equal(t, test.wantErr, gotErr)
+			} else {
+				assert.Equal(t, test.wantSlice, test.list.AsSlice())
+				assert.Equal(t, len(test.wantSlice), test.list.Len())
+			}
+		})
+	}
+}
+
+func TestSkipList_Delete(t *testing.T) {
+	tests := []struct {
+		name        string
+		list        *SkipList[int]
+		index       int
+		wantSlice   []int
+		wantElement int
+		wantErr     error
+	}{
+		{
+			name:        "Delete at index 0",
+			list:        NewSkipListOf([]int{235, 346}),
+			index:       0,
+			wantSlice:   []int{346},
+			wantElement: 235,
+		},
+		{
+			name:        "Delete at index middle",
+			list:        NewSkipListOf([]int{478, 235, 346}),
+			index:       1,
+			wantSlice:   []int{478, 346},
+			wantElement: 235,
+		},
+		{
+			name:        "Delete the only element",
+			list:        NewSkipListOf([]int{478}),
+			index:       0,
+			wantSlice:   []int{},
+			wantElement: 478,
+		},
+		{
+			name:    "Delete at out of range index",
+			list:    NewSkipListOf([]int{478, 867, 235, 346}),
+			index:   4,
+			wantErr: errs.NewErrIndexOutOfRange(4, 4),
+		},
+		{
+			name:    "Delete at negative index",
+			list:    NewSkipListOf([]int{235, 346, 345}),
+			index:   -1,
+			wantErr: errs.NewErrIndexOutOfRange(3, -1),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotElement, gotErr := test.list.Delete(test.index)
+			assert.Equal(t, test.wantErr, gotErr)
+			assert.Equal(t, test.wantElement, gotElement)
+			if gotErr == nil {
+				assert.Equal(t, test.wantSlice, test.list.AsSlice())
+			}
+		})
+	}
+}
+
+func TestSkipList_GetSet(t *testing.T) {
+	sl := NewSkipListOf([]int{1, 2, 3})
+
+	val, err := sl.Get(1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, val)
+
+	require.NoError(t, sl.Set(1, 20))
+	val, err = sl.Get(1)
+	require.NoError(t, err)
+	assert.Equal(t, 20, val)
+
+	_, err = sl.Get(3)
+	assert.Equal(t, errs.NewErrIndexOutOfRange(3, 3), err)
+	assert.Equal(t, errs.NewErrIndexOutOfRange(3, -1), sl.Set(-1, 0))
+	assert.Equal(t, []int{1, 20, 3}, sl.AsSlice())
+	assert.Equal(t, 3, sl.Cap())
+}
+
+func TestSkipList_Range(t *testing.T) {
+	sl := NewSkipListOf([]int{1, 2, 3, 4})
+	sum := 0
+	err := sl.Range(func(idx int, val int) error {
+		if idx == 3 {
+			return errors.New("stop")
+		}
+		sum += val
+		return nil
+	})
+	assert.Equal(t, errors.New("stop"), err)
+	assert.Equal(t, 6, sum)
+}
+
+// TestSkipList_Random 随机增删改查，并与 ArrayList 的结果对比
+func TestSkipList_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	sl := NewSkipList[int]()
+	al := NewArrayList[int](0)
+
+	for i := 0; i < 5000; i++ {
+		switch op := r.Intn(4); {
+		case op < 2 || al.Len() == 0:
+			idx := r.Intn(al.Len() + 1)
+			require.NoError(t, sl.Add(idx, i))
+			require.NoError(t, al.Add(idx, i))
+		case op == 2:
+			idx := r.Intn(al.Len())
+			got, err := sl.Delete(idx)
+			require.NoError(t, err)
+			want, err := al.Delete(idx)
+			require.NoError(t, err)
+			require.Equal(t, want, got)
+		default:
+			idx := r.Intn(al.Len())
+			got, err := sl.Get(idx)
+			require.NoError(t, err)
+			want, err := al.Get(idx)
+			require.NoError(t, err)
+			require.Equal(t, want, got)
+		}
+		require.Equal(t, al.Len(), sl.Len())
+	}
+	assert.Equal(t, al.AsSlice(), sl.AsSlice())
+}
@@ -0,0 +1,153 @@
+// Package list
+/**
+* @Project : GenericGo
+* @File    : sorted_skip_list.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/20 16:42
+**/
+
+package list
+
+import genericgo "github.com/HJH0924/GenericGo"
+
+// sortedSkipListNode 是有序跳表的节点，nexts[i] 是第 i 层的后继节点
+type sortedSkipListNode[K any, V any] struct {
+	key   K
+	val   V
+	nexts []*sortedSkipListNode[K, V]
+}
+
+// SortedSkipList 基于跳表实现的有序映射，键按照 compare 从小到大排列，键不允许重复。
+// 与 Redis 的 zset 类似，Insert、Search 和 Delete 的平均时间复杂度都是 O(log n)。
+type SortedSkipList[K any, V any] struct {
+	header  *sortedSkipListNode[K, V] // 头节点，不存储键值对，拥有最大层数的索引
+	level   int                       // 当前跳表的最高层数
+	length  int
+	compare genericgo.Comparator[K]
+}
+
+// Insert 插入一个键值对，如果键已经存在，则更新其对应的值。
+func (Self *SortedSkipList[K, V]) Insert(key K, val V) {
+	update := Self.findPrev(key)
+	if next := update[0].nexts[0]; next != nil && Self.compare(next.key, key) == 0 {
+		next.val = val
+		return
+	}
+
+	level := randomLevel()
+	if level > Self.level {
+		for i := Self.level; i < level; i++ {
+			update[i] = Self.header
+		}
+		Self.level = level
+	}
+
+	newNode := &sortedSkipListNode[K, V]{
+		key:   key,
+		val:   val,
+		nexts: make([]*sortedSkipListNode[K, V], level),
+	}
+	for i := 0; i < level; i++ {
+		newNode.nexts[i] = update[i].nexts[i]
+		update[i].nexts[i] = newNode
+	}
+	Self.length++
+}
+
+// Search 返回键对应的值，第二个返回值表示键是否存在。
+func (Self *SortedSkipList[K, V]) Search(key K) (V, bool) {
+	update := Self.findPrev(key)
+	if next := update[0].nexts[0]; next != nil && Self.compare(next.key, key) == 0 {
+		return next.val, true
+	}
+	return genericgo.Zero[V](), false
+}
+
+// Contains 判断 SortedSkipList 中是否包含指定的键。
+func (Self *SortedSkipList[K, V]) Contains(key K) bool {
+	_, ok := Self.Search(key)
+	return ok
+}
+
+// Delete 删除键对应的键值对，并返回被删除的值，第二个返回值表示键是否存在。
+func (Self *SortedSkipList[K, V]) Delete(key K) (V, bool) {
+	update := Self.findPrev(key)
+	target := update[0].nexts[0]
+	if target == nil || Self.compare(target.key, key) != 0 {
+		return genericgo.Zero[V](), false
+	}
+	for i := 0; i < len(target.nexts); i++ {
+		update[i].nexts[i] = target.nexts[i]
+	}
+	for Self.level > 1 && Self.header.nexts[Self.level-1] == nil {
+		Self.level--
+	}
+	Self.length--
+	return target.val, true
+}
+
+// Len 返回 SortedSkipList 中键值对的数量。
+func (Self *SortedSkipList[K, V]) Len() int {
+	return Self.length
+}
+
+// Keys 按照从小到大的顺序返回所有的键，即使跳表为空，也返回一个长度为0的切片。
+func (Self *SortedSkipList[K, V]) Keys() []K {
+	res := make([]K, 0, Self.length)
+	for p := Self.header.nexts[0]; p != nil; p = p.nexts[0] {
+		res = append(res, p.key)
+	}
+	return res
+}
+
+// Ascend 按照键从小到大的顺序遍历所有的键值对，visit 返回 false 时停止遍历。
+func (Self *SortedSkipList[K, V]) Ascend(visit func(key K, val V) bool) {
+	for p := Self.header.nexts[0]; p != nil; p = p.nexts[0] {
+		if !visit(p.key, p.val) {
+			return
+		}
+	}
+}
+
+// Range 按照键从小到大的顺序遍历 from 和 to 之间的键值对，visit 返回 false 时停止遍历。
+// fromInclusive 和 toInclusive 分别表示是否包含 from 和 to 这两个端点。
+func (Self *SortedSkipList[K, V]) Range(from K, fromInclusive bool, to K, toInclusive bool, visit func(key K, val V) bool) {
+	p := Self.findPrev(from)[0].nexts[0]
+	if p != nil && !fromInclusive && Self.compare(p.key, from) == 0 {
+		p = p.nexts[0]
+	}
+	for ; p != nil; p = p.nexts[0] {
+		cmp := Self.compare(p.key, to)
+		if cmp > 0 || (cmp == 0 && !toInclusive) {
+			return
+		}
+		if !visit(p.key, p.val) {
+			return
+		}
+	}
+}
+
+// findPrev 返回每一层中最后一个键小于 key 的节点
+func (Self *SortedSkipList[K, V]) findPrev(key K) [skipListMaxLevel]*sortedSkipListNode[K, V] {
+	var update [skipListMaxLevel]*sortedSkipListNode[K, V]
+	p := Self.header
+	for i := Self.level - 1; i >= 0; i-- {
+		for p.nexts[i] != nil && Self.compare(p.nexts[i].key, key) < 0 {
+			p = p.nexts[i]
+		}
+		update[i] = p
+	}
+	return update
+}
+
+// NewSortedSkipList 创建一个空的 SortedSkipList，compare 用于比较键的大小。
+func NewSortedSkipList[K any, V any](compare genericgo.Comparator[K]) *SortedSkipList[K, V] {
+	return &SortedSkipList[K, V]{
+		header: &sortedSkipListNode[K, V]{
+			nexts: make([]*sortedSkipListNode[K, V], skipListMaxLevel),
+		},
+		level:   1,
+		compare: compare,
+	}
+}
@@ -0,0 +1,114 @@
+// Package list
+/**
+* @Project : GenericGo
+* @File    : sorted_skip_list_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/21 09:55
+**/
+
+package list
+
+import (
+	"cmp"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedSkipList_InsertSearchDelete(t *testing.T) {
+	sl := NewSortedSkipList[int, string](cmp.Compare[int])
+	sl.Insert(3, "c")
+	sl.Insert(1, "a")
+	sl.Insert(2, "b")
+	sl.Insert(3, "cc")
+
+	assert.Equal(t, 3, sl.Len())
+	assert.Equal(t, []int{1, 2, 3}, sl.Keys())
+
+	val, ok := sl.Search(3)
+	assert.True(t, ok)
+	assert.Equal(t, "cc", val)
+	_, ok = sl.Search(4)
+	assert.False(t, ok)
+
+	val, ok = sl.Delete(2)
+	assert.True(t, ok)
+	assert.Equal(t, "b", val)
+	assert.False(t, sl.Contains(2))
+	_, ok = sl.Delete(2)
+	assert.False(t, ok)
+	assert.Equal(t, []int{1, 3}, sl.Keys())
+}
+
+func TestSortedSkipList_Range(t *testing.T) {
+	sl := NewSortedSkipList[int, int](cmp.Compare[int])
+	for _, key := range []int{50, 10, 40, 20, 30} {
+		sl.Insert(key, key)
+	}
+
+	tests := []struct {
+		name          string
+		from          int
+		fromInclusive bool
+		to            int
+		toInclusive   bool
+		want          []int
+	}{
+		{name: "closed", from: 20, fromInclusive: true, to: 40, toInclusive: true, want: []int{20, 30, 40}},
+		{name: "open", from: 20, to: 40, want: []int{30}},
+		{name: "bounds not in list", from: 15, to: 45, want: []int{20, 30, 40}},
+		{name: "reversed bounds", from: 40, to: 20, want: []int{}},
+		{name: "all", from: 0, to: 100, want: []int{10, 20, 30, 40, 50}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := make([]int, 0)
+			sl.Range(tt.from, tt.fromInclusive, tt.to, tt.toInclusive, func(key int, val int) bool {
+				res = append(res, key)
+				return true
+			})
+			assert.Equal(t, tt.want, res)
+		})
+	}
+
+	// 提前停止
+	res := make([]int, 0)
+	sl.Ascend(func(key int, val int) bool {
+		res = append(res, key)
+		return len(res) < 2
+	})
+	assert.Equal(t, []int{10, 20}, res)
+}
+
+func TestSortedSkipList_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	sl := NewSortedSkipList[int, int](cmp.Compare[int])
+	m := make(map[int]int)
+
+	for i := 0; i < 5000; i++ {
+		key := r.Intn(500)
+		if r.Intn(3) == 0 {
+			got, ok := sl.Delete(key)
+			want, wantOk := m[key]
+			require.Equal(t, wantOk, ok)
+			require.Equal(t, want, got)
+			delete(m, key)
+		} else {
+			sl.Insert(key, i)
+			m[key] = i
+		}
+		require.Equal(t, len(m), sl.Len())
+	}
+
+	wantKeys := make([]int, 0, len(m))
+	for key := range m {
+		wantKeys = append(wantKeys, key)
+	}
+	sort.Ints(wantKeys)
+	assert.Equal(t, wantKeys, sl.Keys())
+}
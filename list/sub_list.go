@@ -0,0 +1,150 @@
+// Package list
+/**
+* @Project : GenericGo
+* @File    : sub_list.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/6 10:20
+**/
+
+package list
+
+import (
+	"errors"
+
+	"github.com/HJH0924/GenericGo/errs"
+)
+
+var (
+	_ List[any] = &SubList[any]{}
+)
+
+// errStopRange 用于在 Range 中提前结束遍历
+var errStopRange = errors.New("stop range")
+
+// SubList 是父 List 中一段连续元素的视图，不会复制元素。
+// 通过视图进行的读写都会直接作用在父 List 上，通过视图增删元素时，视图的长度也会随之变化。
+// 与 Java 的 subList 一样，如果在视图之外对父 List 进行了增删，视图的行为是未定义的。
+type SubList[T any] struct {
+	parent List[T]
+	offset int // 视图的第一个元素在父 List 中的下标
+	length int
+}
+
+// Append 在视图末尾追加一个或多个元素，元素会被插入到父 List 中视图的末尾处。
+func (sl *SubList[T]) Append(vals ...T) {
+	for _, val := range vals {
+		// 视图的范围总是合法的，这里不会返回错误
+		_ = sl.parent.Add(sl.offset+sl.length, val)
+		sl.length++
+	}
+}
+
+// Add 在视图的特定下标处增加一个新元素。
+// 如果下标超出合法范围，返回错误。
+// 如果 idx 等于视图长度，则表示往视图末端增加元素。
+func (sl *SubList[T]) Add(idx int, val T) error {
+	if idx < 0 || idx > sl.length {
+		return errs.NewErrIndexOutOfRange(sl.length, idx)
+	}
+	if err := sl.parent.Add(sl.offset+idx, val); err != nil {
+		return err
+	}
+	sl.length++
+	return nil
+}
+
+// Delete 删除视图中指定下标的元素，并返回被删除的元素。
+// 如果下标超出合法范围，返回错误。
+func (sl *SubList[T]) Delete(idx int) (T, error) {
+	if idx < 0 || idx >= sl.length {
+		var zero T
+		return zero, errs.NewErrIndexOutOfRange(sl.length, idx)
+	}
+	val, err := sl.parent.Delete(sl.offset + idx)
+	if err != nil {
+		return val, err
+	}
+	sl.length--
+	return val, nil
+}
+
+// Set 重置视图中指定下标位置的元素为 val。
+// 如果下标超出合法范围，返回错误。
+func (sl *SubList[T]) Set(idx int, val T) error {
+	if idx < 0 || idx >= sl.length {
+		return errs.NewErrIndexOutOfRange(sl.length, idx)
+	}
+	return sl.parent.Set(sl.offset+idx, val)
+}
+
+// Get 返回视图中对应下标的元素。
+// 如果下标超出合法范围，返回错误。
+func (sl *SubList[T]) Get(idx int) (T, error) {
+	if idx < 0 || idx >= sl.length {
+		var zero T
+		return zero, errs.NewErrIndexOutOfRange(sl.length, idx)
+	}
+	return sl.parent.Get(sl.offset + idx)
+}
+
+// Len 返回视图中元素的数量。
+func (sl *SubList[T]) Len() int {
+	return sl.length
+}
+
+// Cap 返回视图的容量，视图的容量等于其长度。
+func (sl *SubList[T]) Cap() int {
+	return sl.length
+}
+
+// Range 遍历视图的所有元素，并使用给定的函数访问每个元素，idx 为元素在视图中的下标。
+func (sl *SubList[T]) Range(onVal func(idx int, val T) error) error {
+	end := sl.offset + sl.length
+	err := sl.parent.Range(func(idx int, val T) error {
+		if idx < sl.offset {
+			return nil
+		}
+		if idx >= end {
+			return errStopRange
+		}
+		return onVal(idx-sl.offset, val)
+	})
+	if errors.Is(err, errStopRange) {
+		return nil
+	}
+	return err
+}
+
+// AsSlice 将视图转化为一个新切片，即使视图为空，也返回一个长度和容量都为0的切片。
+func (sl *SubList[T]) AsSlice() []T {
+	res := make([]T, 0, sl.length)
+	_ = sl.Range(func(idx int, val T) error {
+		res = append(res, val)
+		return nil
+	})
+	return res
+}
+
+// SubList 返回视图中下标在 [from, to) 之间的元素的视图。
+// 如果下标超出合法范围，返回错误。
+func (sl *SubList[T]) SubList(from, to int) (*SubList[T], error) {
+	return NewSubList[T](sl, from, to)
+}
+
+// NewSubList 返回 parent 中下标在 [from, to) 之间的元素的视图。
+// 如果 from < 0、to > parent.Len() 或者 from > to，返回错误。
+func NewSubList[T any](parent List[T], from, to int) (*SubList[T], error) {
+	length := parent.Len()
+	if from < 0 || from > length {
+		return nil, errs.NewErrIndexOutOfRange(length, from)
+	}
+	if to < from || to > length {
+		return nil, errs.NewErrIndexOutOfRange(length, to)
+	}
+	return &SubList[T]{
+		parent: parent,
+		offset: from,
+		length: to - from,
+	}, nil
+}
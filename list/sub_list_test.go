@@ -0,0 +1,165 @@
+// Package list
+/**
+* @Project : GenericGo
+* @File    : sub_list_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/6 11:30
+**/
+
+package list
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newParentLists 返回使用相同元素构造的不同 List 实现
+func newParentLists(vals []int) map[string]List[int] {
+	arrayVals := make([]int, len(vals))
+	copy(arrayVals, vals)
+	return map[string]List[int]{
+		"ArrayList":  NewArrayListOf[int](arrayVals),
+		"LinkedList": NewLinkedListOf[int](vals),
+		"SkipList":   NewSkipListOf[int](vals),
+	}
+}
+
+func TestNewSubList(t *testing.T) {
+	tests := []struct {
+		name      string
+		from      int
+		to        int
+		wantSlice []int
+		wantErr   error
+	}{
+		{
+			name:      "Whole list",
+			from:      0,
+			to:        5,
+			wantSlice: []int{0, 1, 2, 3, 4},
+		},
+		{
+			name:      "Middle",
+			from:      1,
+			to:        4,
+			wantSlice: []int{1, 2, 3},
+		},
+		{
+			name:      "Empty view",
+			from:      2,
+			to:        2,
+			wantSlice: []int{},
+		},
+		{
+			name:    "Negative from",
+			from:    -1,
+			to:      2,
+			wantErr: errs.NewErrIndexOutOfRange(5, -1),
+		},
+		{
+			name:    "To out of range",
+			from:    0,
+			to:      6,
+			wantErr: errs.NewErrIndexOutOfRange(5, 6),
+		},
+		{
+			name:    "From greater than to",
+			from:    3,
+			to:      2,
+			wantErr: errs.NewErrIndexOutOfRange(5, 2),
+		},
+	}
+
+	for _, tt := range tests {
+		for parentName, parent := range newParentLists([]int{0, 1, 2, 3, 4}) {
+			t.Run(parentName+" "+tt.name, func(t *testing.T) {
+				sl, err := NewSubList(parent, tt.from, tt.to)
+				assert.Equal(t, tt.wantErr, err)
+				if err != nil {
+					return
+				}
+				assert.Equal(t, tt.wantSlice, sl.AsSlice())
+				assert.Equal(t, len(tt.wantSlice), sl.Len())
+			})
+		}
+	}
+}
+
+func TestSubList_WriteThrough(t *testing.T) {
+	for parentName, parent := range newParentLists([]int{0, 1, 2, 3, 4, 5}) {
+		t.Run(parentName, func(t *testing.T) {
+			sl, err := NewSubList(parent, 1, 4)
+			require.NoError(t, err)
+
+			// Set 和 Get
+			require.NoError(t, sl.Set(0, 10))
+			val, err := sl.Get(0)
+			require.NoError(t, err)
+			assert.Equal(t, 10, val)
+			assert.Equal(t, []int{0, 10, 2, 3, 4, 5}, parent.AsSlice())
+
+			// Add 和 Append 会插入到父 List 中，并增加视图的长度
+			require.NoError(t, sl.Add(1, 11))
+			sl.Append(12)
+			assert.Equal(t, []int{10, 11, 2, 3, 12}, sl.AsSlice())
+			assert.Equal(t, []int{0, 10, 11, 2, 3, 12, 4, 5}, parent.AsSlice())
+
+			// Delete 会从父 List 中删除，并减少视图的长度
+			val, err = sl.Delete(2)
+			require.NoError(t, err)
+			assert.Equal(t, 2, val)
+			assert.Equal(t, []int{10, 11, 3, 12}, sl.AsSlice())
+			assert.Equal(t, []int{0, 10, 11, 3, 12, 4, 5}, parent.AsSlice())
+
+			// 越界访问
+			_, err = sl.Get(4)
+			assert.Equal(t, errs.NewErrIndexOutOfRange(4, 4), err)
+			assert.Equal(t, errs.NewErrIndexOutOfRange(4, -1), sl.Set(-1, 0))
+			assert.Equal(t, errs.NewErrIndexOutOfRange(4, 5), sl.Add(5, 0))
+			_, err = sl.Delete(4)
+			assert.Equal(t, errs.NewErrIndexOutOfRange(4, 4), err)
+		})
+	}
+}
+
+func TestSubList_Range(t *testing.T) {
+	parent := NewLinkedListOf[int]([]int{0, 1, 2, 3, 4, 5})
+	sl, err := parent.SubList(2, 5)
+	require.NoError(t, err)
+
+	var idxs, vals []int
+	err = sl.Range(func(idx int, val int) error {
+		idxs = append(idxs, idx)
+		vals = append(vals, val)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2}, idxs)
+	assert.Equal(t, []int{2, 3, 4}, vals)
+
+	wantErr := errors.New("stop")
+	err = sl.Range(func(idx int, val int) error {
+		return wantErr
+	})
+	assert.Equal(t, wantErr, err)
+}
+
+func TestSubList_Nested(t *testing.T) {
+	parent := NewArrayListOf[int]([]int{0, 1, 2, 3, 4, 5, 6, 7})
+	page, err := parent.SubList(2, 7)
+	require.NoError(t, err)
+	inner, err := page.SubList(1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 4}, inner.AsSlice())
+
+	require.NoError(t, inner.Add(0, 100))
+	assert.Equal(t, []int{100, 3, 4}, inner.AsSlice())
+	assert.Equal(t, 6, page.Len())
+	assert.Equal(t, []int{2, 100, 3, 4, 5, 6}, page.AsSlice())
+	assert.Equal(t, []int{0, 1, 2, 100, 3, 4, 5, 6, 7}, parent.AsSlice())
+}
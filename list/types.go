@@ -43,3 +43,32 @@ type List[T any] interface {
 	// AsSlice 将 List 转化为一个新切片，即使 List 为空，也返回一个长度和容量都为0的切片。
 	AsSlice() []T
 }
+
+// BulkList 在 List 的基础上提供批量和按条件修改的操作，
+// 具体实现可以借助自身的存储结构，提供比循环调用 Add 和 Delete 更高效的版本。
+type BulkList[T any] interface {
+	List[T]
+
+	// RemoveIf 删除所有满足 match 的元素，并返回被删除的元素个数。
+	// 可能会发生缩容。
+	RemoveIf(match func(val T) bool) int
+
+	// InsertAll 在特定下标处按顺序插入多个元素。
+	// 如果下标超出合法范围，返回错误。
+	// 如果 idx 等于 List 长度，则表示往 List 末端追加元素。
+	InsertAll(idx int, vals ...T) error
+
+	// Clear 删除 List 中的所有元素。
+	Clear()
+
+	// IndexOf 返回第一个与 val 相等的元素的下标，使用 equal 判断两个元素是否相等。
+	// 如果不存在，返回 -1。
+	IndexOf(val T, equal func(left, right T) bool) int
+
+	// Contains 判断 List 中是否存在与 val 相等的元素，使用 equal 判断两个元素是否相等。
+	Contains(val T, equal func(left, right T) bool) bool
+
+	// Swap 交换下标 i 和 j 处的元素。
+	// 如果下标超出合法范围，返回错误。
+	Swap(i, j int) error
+}
@@ -0,0 +1,104 @@
+// Package mapx
+/**
+* @Project : GenericGo
+* @File    : treemap.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/18 10:35
+**/
+
+package mapx
+
+import (
+	genericgo "github.com/HJH0924/GenericGo"
+	"github.com/HJH0924/GenericGo/tree"
+)
+
+// TreeMap 是基于红黑树实现的有序映射，键按照 compare 从小到大排列。
+// 与 Go 内置的 map 不同，TreeMap 的键不要求可比较，遍历顺序也是确定的。
+type TreeMap[K any, V any] struct {
+	tree *tree.RBTree[K, V]
+}
+
+// Put 添加一个键值对，如果键已经存在，则更新其对应的值。
+func (Self *TreeMap[K, V]) Put(key K, val V) {
+	Self.tree.Put(key, val)
+}
+
+// Get 返回键对应的值，第二个返回值表示键是否存在。
+func (Self *TreeMap[K, V]) Get(key K) (V, bool) {
+	return Self.tree.Get(key)
+}
+
+// Delete 删除键对应的键值对，并返回被删除的值，第二个返回值表示键是否存在。
+func (Self *TreeMap[K, V]) Delete(key K) (V, bool) {
+	return Self.tree.Delete(key)
+}
+
+// Contains 判断 TreeMap 中是否包含指定的键。
+func (Self *TreeMap[K, V]) Contains(key K) bool {
+	return Self.tree.Contains(key)
+}
+
+// Size 返回 TreeMap 中键值对的数量。
+func (Self *TreeMap[K, V]) Size() int {
+	return Self.tree.Size()
+}
+
+// Keys 按照从小到大的顺序返回所有的键。
+func (Self *TreeMap[K, V]) Keys() []K {
+	return Self.tree.Keys()
+}
+
+// Values 按照键从小到大的顺序返回所有的值。
+func (Self *TreeMap[K, V]) Values() []V {
+	return Self.tree.Values()
+}
+
+// Min 返回最小的键及其对应的值，TreeMap 为空时第三个返回值为 false。
+func (Self *TreeMap[K, V]) Min() (K, V, bool) {
+	return Self.tree.Min()
+}
+
+// Max 返回最大的键及其对应的值，TreeMap 为空时第三个返回值为 false。
+func (Self *TreeMap[K, V]) Max() (K, V, bool) {
+	return Self.tree.Max()
+}
+
+// Floor 返回小于等于 key 的最大键及其对应的值，不存在时第三个返回值为 false。
+func (Self *TreeMap[K, V]) Floor(key K) (K, V, bool) {
+	return Self.tree.Floor(key)
+}
+
+// Ceiling 返回大于等于 key 的最小键及其对应的值，不存在时第三个返回值为 false。
+func (Self *TreeMap[K, V]) Ceiling(key K) (K, V, bool) {
+	return Self.tree.Ceiling(key)
+}
+
+// Lower 返回严格小于 key 的最大键及其对应的值，不存在时第三个返回值为 false。
+func (Self *TreeMap[K, V]) Lower(key K) (K, V, bool) {
+	return Self.tree.Lower(key)
+}
+
+// Higher 返回严格大于 key 的最小键及其对应的值，不存在时第三个返回值为 false。
+func (Self *TreeMap[K, V]) Higher(key K) (K, V, bool) {
+	return Self.tree.Higher(key)
+}
+
+// Range 按照从小到大的顺序遍历所有的键值对，visit 返回 false 时停止遍历。
+func (Self *TreeMap[K, V]) Range(visit func(key K, val V) bool) {
+	Self.tree.Ascend(visit)
+}
+
+// RangeBetween 按照从小到大的顺序遍历 from 和 to 之间的键值对，visit 返回 false 时停止遍历。
+// fromInclusive 和 toInclusive 分别表示是否包含 from 和 to 这两个端点。
+func (Self *TreeMap[K, V]) RangeBetween(from K, fromInclusive bool, to K, toInclusive bool, visit func(key K, val V) bool) {
+	Self.tree.Range(from, fromInclusive, to, toInclusive, visit)
+}
+
+// NewTreeMap 创建一个空的 TreeMap，compare 用于比较键的大小。
+func NewTreeMap[K any, V any](compare genericgo.Comparator[K]) *TreeMap[K, V] {
+	return &TreeMap[K, V]{
+		tree: tree.NewRBTree[K, V](compare),
+	}
+}
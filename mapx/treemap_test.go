@@ -0,0 +1,83 @@
+// Package mapx
+/**
+* @Project : GenericGo
+* @File    : treemap_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/18 11:20
+**/
+
+package mapx
+
+import (
+	"cmp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeMap_PutGetDelete(t *testing.T) {
+	treeMap := NewTreeMap[string, int](cmp.Compare[string])
+	treeMap.Put("b", 2)
+	treeMap.Put("c", 3)
+	treeMap.Put("a", 1)
+	treeMap.Put("b", 20)
+
+	assert.Equal(t, 3, treeMap.Size())
+	assert.Equal(t, []string{"a", "b", "c"}, treeMap.Keys())
+	assert.Equal(t, []int{1, 20, 3}, treeMap.Values())
+
+	val, ok := treeMap.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, 20, val)
+	assert.True(t, treeMap.Contains("c"))
+
+	val, ok = treeMap.Delete("c")
+	assert.True(t, ok)
+	assert.Equal(t, 3, val)
+	assert.False(t, treeMap.Contains("c"))
+
+	_, ok = treeMap.Delete("c")
+	assert.False(t, ok)
+	_, ok = treeMap.Get("d")
+	assert.False(t, ok)
+}
+
+func TestTreeMap_Navigation(t *testing.T) {
+	treeMap := NewTreeMap[int, string](cmp.Compare[int])
+	for _, key := range []int{10, 20, 30} {
+		treeMap.Put(key, "v")
+	}
+
+	key, _, ok := treeMap.Min()
+	assert.True(t, ok)
+	assert.Equal(t, 10, key)
+	key, _, ok = treeMap.Max()
+	assert.True(t, ok)
+	assert.Equal(t, 30, key)
+
+	key, _, _ = treeMap.Floor(25)
+	assert.Equal(t, 20, key)
+	key, _, _ = treeMap.Ceiling(25)
+	assert.Equal(t, 30, key)
+	key, _, _ = treeMap.Lower(20)
+	assert.Equal(t, 10, key)
+	key, _, _ = treeMap.Higher(20)
+	assert.Equal(t, 30, key)
+	_, _, ok = treeMap.Higher(30)
+	assert.False(t, ok)
+
+	var keys []int
+	treeMap.Range(func(key int, val string) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []int{10, 20, 30}, keys)
+
+	keys = nil
+	treeMap.RangeBetween(10, false, 30, true, func(key int, val string) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []int{20, 30}, keys)
+}
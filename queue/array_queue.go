@@ -0,0 +1,156 @@
+// Package queue
+/**
+* @Project : GenericGo
+* @File    : array_queue.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/25 10:20
+**/
+
+package queue
+
+import (
+	genericgo "github.com/HJH0924/GenericGo"
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/slice"
+)
+
+var (
+	_ Queue[any] = &ArrayQueue[any]{}
+)
+
+// ArrayQueue 是一个基于环形缓冲区实现的先进先出队列
+// 当 capacity <= 0 时，为无界队列，缓冲区会动态扩缩容
+// 当 capacity > 0 时，为有界队列，初始化后就固定容量，不会扩缩容
+type ArrayQueue[T any] struct {
+	vals     []T // 环形缓冲区，len(vals) 即缓冲区的大小
+	head     int // 队首元素在缓冲区中的下标
+	count    int // 队列中元素的数量
+	capacity int // 队列的容量
+}
+
+// Len 返回队列中元素的数量。
+func (Self *ArrayQueue[T]) Len() int {
+	return Self.count
+}
+
+// Cap 返回队列的容量，无界队列返回0。
+func (Self *ArrayQueue[T]) Cap() int {
+	return Self.capacity
+}
+
+// IsBoundLess 返回队列是否无界，即容量是否小于或等于0。
+func (Self *ArrayQueue[T]) IsBoundLess() bool {
+	return Self.capacity <= 0
+}
+
+// IsEmpty 返回队列是否为空。
+func (Self *ArrayQueue[T]) IsEmpty() bool {
+	return Self.count == 0
+}
+
+// IsFull 返回队列是否已满。
+func (Self *ArrayQueue[T]) IsFull() bool {
+	return Self.capacity > 0 && Self.count == Self.capacity
+}
+
+// Peek 返回队首元素，但不移除它。如果队列为空，返回错误。
+func (Self *ArrayQueue[T]) Peek() (T, error) {
+	if Self.IsEmpty() {
+		return genericgo.Zero[T](), errs.NewErrEmptyQueue()
+	}
+	return Self.vals[Self.head], nil
+}
+
+// EnQueue 将元素放入队尾。如果队列已满，返回错误。
+func (Self *ArrayQueue[T]) EnQueue(val T) error {
+	if Self.IsFull() {
+		return errs.NewErrOutOfCapacity()
+	}
+	if Self.count == len(Self.vals) {
+		// 只有无界队列会走到这里，缓冲区已满，扩容为原来的两倍
+		Self.resize(2 * len(Self.vals))
+	}
+	Self.vals[(Self.head+Self.count)%len(Self.vals)] = val
+	Self.count++
+	return nil
+}
+
+// DeQueue 从队列中移除并返回队首元素。如果队列为空，返回错误。
+func (Self *ArrayQueue[T]) DeQueue() (T, error) {
+	if Self.IsEmpty() {
+		return genericgo.Zero[T](), errs.NewErrEmptyQueue()
+	}
+	val := Self.vals[Self.head]
+	// 释放对元素的引用，方便 GC 回收
+	Self.vals[Self.head] = genericgo.Zero[T]()
+	Self.head = (Self.head + 1) % len(Self.vals)
+	Self.count--
+
+	// 无界队列可能需要缩容
+	if Self.IsBoundLess() {
+		Self.shrink()
+	}
+	return val, nil
+}
+
+// AsSlice 按照出队的顺序返回队列中元素的切片副本。
+func (Self *ArrayQueue[T]) AsSlice() []T {
+	res := make([]T, Self.count)
+	Self.copyTo(res)
+	return res
+}
+
+// copyTo 按照出队的顺序将队列中的元素复制到 dst 中，dst 的长度不能小于 count
+func (Self *ArrayQueue[T]) copyTo(dst []T) {
+	if Self.count == 0 {
+		return
+	}
+	end := Self.head + Self.count
+	if end <= len(Self.vals) {
+		copy(dst, Self.vals[Self.head:end])
+		return
+	}
+	// 元素跨越了缓冲区的末尾，分两段复制
+	n := copy(dst, Self.vals[Self.head:])
+	copy(dst[n:], Self.vals[:end-len(Self.vals)])
+}
+
+// resize 将缓冲区的大小调整为 size，并将元素移动到缓冲区的开头
+func (Self *ArrayQueue[T]) resize(size int) {
+	newVals := make([]T, size)
+	Self.copyTo(newVals)
+	Self.vals = newVals
+	Self.head = 0
+}
+
+// shrink 使用 slice.ShrinkSlice 的缩容策略决定是否需要缩小缓冲区
+func (Self *ArrayQueue[T]) shrink() {
+	// ShrinkSlice 根据长度和容量决定是否缩容，需要缩容时返回一个容量更小的新切片
+	// 环形缓冲区中的元素不一定从下标0开始，所以只借用它的容量，元素由 copyTo 重新按顺序复制
+	newVals := slice.ShrinkSlice(Self.vals[:Self.count])
+	if cap(newVals) >= len(Self.vals) {
+		return
+	}
+	newVals = newVals[:cap(newVals)]
+	Self.copyTo(newVals)
+	Self.vals = newVals
+	Self.head = 0
+}
+
+// NewArrayQueue 创建一个新的 ArrayQueue。
+// 当 capacity <= 0 时，视为无界队列，初始大小使用默认值64。
+func NewArrayQueue[T any](capacity int) *ArrayQueue[T] {
+	const (
+		DefaultInitialCap = 64
+	)
+	initialCap := capacity
+	if capacity <= 0 {
+		capacity = 0
+		initialCap = DefaultInitialCap
+	}
+	return &ArrayQueue[T]{
+		vals:     make([]T, initialCap),
+		capacity: capacity,
+	}
+}
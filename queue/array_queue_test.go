@@ -0,0 +1,211 @@
+// Package queue
+/**
+* @Project : GenericGo
+* @File    : array_queue_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/25 11:05
+**/
+
+package queue
+
+import (
+	"testing"
+
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewArrayQueue(t *testing.T) {
+	tests := []struct {
+		name        string
+		capacity    int
+		wantCap     int
+		wantBufSize int
+	}{
+		{
+			name:        "Test with bounded capacity",
+			capacity:    10,
+			wantCap:     10,
+			wantBufSize: 10,
+		},
+		{
+			name:        "Test with zero capacity",
+			capacity:    0,
+			wantCap:     0,
+			wantBufSize: 64,
+		},
+		{
+			name:        "Test with negative capacity",
+			capacity:    -1,
+			wantCap:     0,
+			wantBufSize: 64,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q := NewArrayQueue[int](test.capacity)
+			assert.Equal(t, test.wantCap, q.Cap())
+			assert.Equal(t, test.wantBufSize, len(q.vals))
+			assert.Equal(t, 0, q.Len())
+			assert.True(t, q.IsEmpty())
+		})
+	}
+}
+
+func TestArrayQueue_EnQueue(t *testing.T) {
+	tests := []struct {
+		name      string
+		capacity  int
+		vals      []int
+		wantSlice []int
+		wantErr   error
+	}{
+		{
+			name:      "Test bounded queue not full",
+			capacity:  5,
+			vals:      []int{1, 2, 3},
+			wantSlice: []int{1, 2, 3},
+		},
+		{
+			name:      "Test bounded queue full",
+			capacity:  3,
+			vals:      []int{1, 2, 3, 4},
+			wantSlice: []int{1, 2, 3},
+			wantErr:   errs.NewErrOutOfCapacity(),
+		},
+		{
+			name:      "Test unbounded queue grows",
+			capacity:  0,
+			vals:      makeRange(0, 100),
+			wantSlice: makeRange(0, 100),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q := NewArrayQueue[int](test.capacity)
+			var err error
+			for _, val := range test.vals {
+				if err = q.EnQueue(val); err != nil {
+					break
+				}
+			}
+			assert.Equal(t, test.wantErr, err)
+			assert.Equal(t, test.wantSlice, q.AsSlice())
+			assert.Equal(t, len(test.wantSlice), q.Len())
+		})
+	}
+}
+
+func TestArrayQueue_DeQueue(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		vals     []int
+		wantVals []int
+	}{
+		{
+			name:     "Test empty queue",
+			capacity: 3,
+			vals:     []int{},
+			wantVals: []int{},
+		},
+		{
+			name:     "Test bounded queue",
+			capacity: 3,
+			vals:     []int{1, 2, 3},
+			wantVals: []int{1, 2, 3},
+		},
+		{
+			name:     "Test unbounded queue",
+			capacity: 0,
+			vals:     makeRange(0, 200),
+			wantVals: makeRange(0, 200),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q := NewArrayQueue[int](test.capacity)
+			for _, val := range test.vals {
+				require.NoError(t, q.EnQueue(val))
+			}
+			res := make([]int, 0, len(test.vals))
+			for !q.IsEmpty() {
+				val, err := q.DeQueue()
+				require.NoError(t, err)
+				res = append(res, val)
+			}
+			assert.Equal(t, test.wantVals, res)
+
+			_, err := q.DeQueue()
+			assert.Equal(t, errs.NewErrEmptyQueue(), err)
+		})
+	}
+}
+
+func TestArrayQueue_Peek(t *testing.T) {
+	q := NewArrayQueue[int](2)
+	_, err := q.Peek()
+	assert.Equal(t, errs.NewErrEmptyQueue(), err)
+
+	require.NoError(t, q.EnQueue(1))
+	require.NoError(t, q.EnQueue(2))
+	val, err := q.Peek()
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+	// Peek 不会移除元素
+	assert.Equal(t, 2, q.Len())
+	assert.True(t, q.IsFull())
+}
+
+func TestArrayQueue_WrapAround(t *testing.T) {
+	// 有界队列反复出队和入队，元素会跨越缓冲区的末尾
+	q := NewArrayQueue[int](4)
+	for i := 0; i < 4; i++ {
+		require.NoError(t, q.EnQueue(i))
+	}
+	for i := 4; i < 20; i++ {
+		val, err := q.DeQueue()
+		require.NoError(t, err)
+		assert.Equal(t, i-4, val)
+		require.NoError(t, q.EnQueue(i))
+		assert.Equal(t, makeRange(i-3, i+1), q.AsSlice())
+	}
+}
+
+func TestArrayQueue_Shrink(t *testing.T) {
+	q := NewArrayQueue[int](0)
+	for i := 0; i < 4096; i++ {
+		require.NoError(t, q.EnQueue(i))
+	}
+	grownSize := len(q.vals)
+	assert.GreaterOrEqual(t, grownSize, 4096)
+
+	for i := 0; i < 4000; i++ {
+		val, err := q.DeQueue()
+		require.NoError(t, err)
+		require.Equal(t, i, val)
+	}
+	// 出队后缓冲区应当缩容，并且剩余元素的顺序不变
+	assert.Less(t, len(q.vals), grownSize)
+	assert.Equal(t, makeRange(4000, 4096), q.AsSlice())
+
+	// 缩容后继续入队，缓冲区可以再次扩容
+	for i := 4096; i < 5000; i++ {
+		require.NoError(t, q.EnQueue(i))
+	}
+	assert.Equal(t, makeRange(4000, 5000), q.AsSlice())
+}
+
+// makeRange 返回 [start, end) 之间的整数切片
+func makeRange(start, end int) []int {
+	res := make([]int, 0, end-start)
+	for i := start; i < end; i++ {
+		res = append(res, i)
+	}
+	return res
+}
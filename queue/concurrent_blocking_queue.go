@@ -0,0 +1,120 @@
+// Package queue
+/**
+* @Project : GenericGo
+* @File    : concurrent_blocking_queue.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/26 10:15
+**/
+
+package queue
+
+import (
+	"context"
+	"sync"
+
+	genericgo "github.com/HJH0924/GenericGo"
+)
+
+var (
+	_ BlockingQueue[any] = &ConcurrentBlockingQueue[any]{}
+)
+
+// ConcurrentBlockingQueue 并发安全的阻塞队列
+// 队列为空时 DeQueue 会阻塞，有界队列已满时 EnQueue 会阻塞，直到条件满足或者 ctx 结束。
+// 元素的出队顺序由底层的 Queue 决定，可以是先进先出，也可以按照优先级。
+type ConcurrentBlockingQueue[T any] struct {
+	queue    Queue[T]
+	mutex    sync.Mutex
+	notEmpty *cond // 有元素入队时广播
+	notFull  *cond // 有元素出队时广播
+}
+
+// EnQueue 将元素放入队列，如果队列已满，则阻塞直到有空位或者 ctx 结束。
+func (Self *ConcurrentBlockingQueue[T]) EnQueue(ctx context.Context, val T) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	for Self.queue.IsFull() {
+		if err := Self.notFull.wait(ctx); err != nil {
+			return err
+		}
+	}
+	if err := Self.queue.EnQueue(val); err != nil {
+		return err
+	}
+	Self.notEmpty.broadcast()
+	return nil
+}
+
+// DeQueue 从队列中移除并返回队首元素，如果队列为空，则阻塞直到有元素或者 ctx 结束。
+func (Self *ConcurrentBlockingQueue[T]) DeQueue(ctx context.Context) (T, error) {
+	if ctx.Err() != nil {
+		return genericgo.Zero[T](), ctx.Err()
+	}
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	for Self.queue.IsEmpty() {
+		if err := Self.notEmpty.wait(ctx); err != nil {
+			return genericgo.Zero[T](), err
+		}
+	}
+	val, err := Self.queue.DeQueue()
+	if err != nil {
+		return genericgo.Zero[T](), err
+	}
+	Self.notFull.broadcast()
+	return val, nil
+}
+
+// Peek 返回队首元素，但不移除它。如果队列为空，不会阻塞，直接返回错误。
+func (Self *ConcurrentBlockingQueue[T]) Peek() (T, error) {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	return Self.queue.Peek()
+}
+
+// Len 返回队列中元素的数量。
+func (Self *ConcurrentBlockingQueue[T]) Len() int {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	return Self.queue.Len()
+}
+
+// IsEmpty 返回队列是否为空。
+func (Self *ConcurrentBlockingQueue[T]) IsEmpty() bool {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	return Self.queue.IsEmpty()
+}
+
+// IsFull 返回队列是否已满。
+func (Self *ConcurrentBlockingQueue[T]) IsFull() bool {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	return Self.queue.IsFull()
+}
+
+// newConcurrentBlockingQueue 使用给定的非并发安全的 Queue 创建阻塞队列
+func newConcurrentBlockingQueue[T any](queue Queue[T]) *ConcurrentBlockingQueue[T] {
+	res := &ConcurrentBlockingQueue[T]{
+		queue: queue,
+	}
+	res.notEmpty = newCond(&res.mutex)
+	res.notFull = newCond(&res.mutex)
+	return res
+}
+
+// NewConcurrentBlockingQueue 创建一个先进先出的阻塞队列，底层使用 ArrayQueue。
+// 当 capacity <= 0 时，视为无界队列，EnQueue 永远不会阻塞。
+func NewConcurrentBlockingQueue[T any](capacity int) *ConcurrentBlockingQueue[T] {
+	return newConcurrentBlockingQueue[T](NewArrayQueue[T](capacity))
+}
+
+// NewConcurrentBlockingPriorityQueue 创建一个按照优先级出队的阻塞队列，底层使用 PriorityQueue。
+// 当 capacity <= 0 时，视为无界队列，EnQueue 永远不会阻塞。
+func NewConcurrentBlockingPriorityQueue[T any](capacity int, compare genericgo.Comparator[T]) *ConcurrentBlockingQueue[T] {
+	return newConcurrentBlockingQueue[T](NewPriorityQueue[T](capacity, compare))
+}
@@ -0,0 +1,190 @@
+// Package queue
+/**
+* @Project : GenericGo
+* @File    : concurrent_blocking_queue_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/26 11:02
+**/
+
+package queue
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrentBlockingQueue_DeQueueTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		q    *ConcurrentBlockingQueue[int]
+	}{
+		{
+			name: "Test FIFO queue",
+			q:    NewConcurrentBlockingQueue[int](3),
+		},
+		{
+			name: "Test priority queue",
+			q:    NewConcurrentBlockingPriorityQueue[int](3, getIntComparator()),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			_, err := test.q.DeQueue(ctx)
+			assert.Equal(t, context.DeadlineExceeded, err)
+		})
+	}
+}
+
+func TestConcurrentBlockingQueue_EnQueueTimeout(t *testing.T) {
+	q := NewConcurrentBlockingQueue[int](2)
+	require.NoError(t, q.EnQueue(context.Background(), 1))
+	require.NoError(t, q.EnQueue(context.Background(), 2))
+	assert.True(t, q.IsFull())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := q.EnQueue(ctx, 3)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, 2, q.Len())
+}
+
+func TestConcurrentBlockingQueue_CanceledContext(t *testing.T) {
+	q := NewConcurrentBlockingQueue[int](0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// ctx 已经结束，即使队列有空位也不会入队
+	assert.Equal(t, context.Canceled, q.EnQueue(ctx, 1))
+	assert.Equal(t, 0, q.Len())
+	_, err := q.DeQueue(ctx)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestConcurrentBlockingQueue_WakeUp(t *testing.T) {
+	t.Run("Test DeQueue waked by EnQueue", func(t *testing.T) {
+		q := NewConcurrentBlockingQueue[int](1)
+		done := make(chan int)
+		go func() {
+			val, err := q.DeQueue(context.Background())
+			assert.NoError(t, err)
+			done <- val
+		}()
+		time.Sleep(20 * time.Millisecond)
+		require.NoError(t, q.EnQueue(context.Background(), 42))
+		assert.Equal(t, 42, <-done)
+	})
+
+	t.Run("Test EnQueue waked by DeQueue", func(t *testing.T) {
+		q := NewConcurrentBlockingQueue[int](1)
+		require.NoError(t, q.EnQueue(context.Background(), 1))
+		done := make(chan struct{})
+		go func() {
+			assert.NoError(t, q.EnQueue(context.Background(), 2))
+			close(done)
+		}()
+		time.Sleep(20 * time.Millisecond)
+		val, err := q.DeQueue(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, val)
+		<-done
+		val, err = q.DeQueue(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 2, val)
+	})
+}
+
+func TestConcurrentBlockingQueue_Order(t *testing.T) {
+	tests := []struct {
+		name     string
+		q        *ConcurrentBlockingQueue[int]
+		vals     []int
+		wantVals []int
+	}{
+		{
+			name:     "Test FIFO order",
+			q:        NewConcurrentBlockingQueue[int](0),
+			vals:     []int{3, 1, 4, 1, 5},
+			wantVals: []int{3, 1, 4, 1, 5},
+		},
+		{
+			name:     "Test priority order",
+			q:        NewConcurrentBlockingPriorityQueue[int](0, getIntComparator()),
+			vals:     []int{3, 1, 4, 1, 5},
+			wantVals: []int{5, 4, 3, 1, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, val := range test.vals {
+				require.NoError(t, test.q.EnQueue(context.Background(), val))
+			}
+			res := make([]int, 0, len(test.vals))
+			for !test.q.IsEmpty() {
+				val, err := test.q.DeQueue(context.Background())
+				require.NoError(t, err)
+				res = append(res, val)
+			}
+			assert.Equal(t, test.wantVals, res)
+		})
+	}
+}
+
+func TestConcurrentBlockingQueue_ProducerConsumer(t *testing.T) {
+	const (
+		producers = 4
+		consumers = 4
+		perWorker = 500
+	)
+	q := NewConcurrentBlockingQueue[int](8)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var producerWg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		producerWg.Add(1)
+		go func(p int) {
+			defer producerWg.Done()
+			for i := 0; i < perWorker; i++ {
+				assert.NoError(t, q.EnQueue(ctx, p*perWorker+i))
+			}
+		}(p)
+	}
+
+	var (
+		consumerWg sync.WaitGroup
+		mutex      sync.Mutex
+		got        = make([]int, 0, producers*perWorker)
+	)
+	for c := 0; c < consumers; c++ {
+		consumerWg.Add(1)
+		go func() {
+			defer consumerWg.Done()
+			for i := 0; i < perWorker; i++ {
+				val, err := q.DeQueue(ctx)
+				if !assert.NoError(t, err) {
+					return
+				}
+				mutex.Lock()
+				got = append(got, val)
+				mutex.Unlock()
+			}
+		}()
+	}
+	producerWg.Wait()
+	consumerWg.Wait()
+
+	sort.Ints(got)
+	assert.Equal(t, makeRange(0, producers*perWorker), got)
+	assert.True(t, q.IsEmpty())
+}
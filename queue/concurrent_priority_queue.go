@@ -15,6 +15,10 @@ import (
 	genericgo "github.com/HJH0924/GenericGo"
 )
 
+var (
+	_ Queue[any] = &ConcurrentPriorityQueue[any]{}
+)
+
 // ConcurrentPriorityQueue 并发安全的优先级队列
 type ConcurrentPriorityQueue[T any] struct {
 	pq     PriorityQueue[T]
@@ -93,9 +97,13 @@ func NewConcurrentPriorityQueue[T any](capacity int, compare genericgo.Comparato
 
 // NewConcurrentPriorityQueueOf 创建一个具有初始值的 ConcurrentPriorityQueue。
 // 它接受一个容量、一个包含初始元素的切片，以及一个比较函数。
-// 如果添加元素时出现错误，将返回nil。
-func NewConcurrentPriorityQueueOf[T any](capacity int, vals []T, compare genericgo.Comparator[T]) *ConcurrentPriorityQueue[T] {
-	return &ConcurrentPriorityQueue[T]{
-		pq: *NewPriorityQueueOf(capacity, vals, compare),
+// 如果初始元素的数量超过了容量，返回 errs.NewErrOutOfCapacity。
+func NewConcurrentPriorityQueueOf[T any](capacity int, vals []T, compare genericgo.Comparator[T]) (*ConcurrentPriorityQueue[T], error) {
+	pq, err := NewPriorityQueueOf(capacity, vals, compare)
+	if err != nil {
+		return nil, err
 	}
+	return &ConcurrentPriorityQueue[T]{
+		pq: *pq,
+	}, nil
 }
@@ -16,6 +16,7 @@ import (
 
 	"github.com/HJH0924/GenericGo/errs"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // 多个 goroutine 执行入队操作，完成后，主协程把元素逐一出队，只要有序，可以认为并发入队没有问题
@@ -128,7 +129,8 @@ func TestConcurrentPriorityQueue_DeQueue(t *testing.T) {
 			}
 
 			// 预先入队一组数据
-			pq := NewConcurrentPriorityQueueOf(tt.numElements, testData, getIntComparator())
+			pq, err := NewConcurrentPriorityQueueOf(tt.numElements, testData, getIntComparator())
+			require.NoError(t, err)
 
 			errChan := make(chan error, tt.numGoroutines*tt.elementsPerGoroutine)
 			disOrderChan := make(chan bool, tt.numGoroutines*tt.elementsPerGoroutine)
@@ -0,0 +1,122 @@
+// Package queue
+/**
+* @Project : GenericGo
+* @File    : concurrent_ring_queue.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/4 09:40
+**/
+
+package queue
+
+import (
+	"sync/atomic"
+
+	genericgo "github.com/HJH0924/GenericGo"
+)
+
+// cacheLineSize 用于填充，避免入队和出队的位置落在同一个缓存行上造成伪共享
+const cacheLineSize = 64
+
+// ringCell 是 ConcurrentRingQueue 中的槽位
+// sequence 等于 pos 时槽位可以写入位置为 pos 的元素，等于 pos+1 时槽位中的元素可以被读取
+type ringCell[T any] struct {
+	sequence atomic.Uint64
+	val      T
+}
+
+// ConcurrentRingQueue 是一个无锁的有界多生产者多消费者队列，基于 Dmitry Vyukov 的环形队列算法。
+// 每个槽位维护一个序号，生产者和消费者只通过 CAS 竞争入队和出队的位置，不需要任何锁，
+// 适合高吞吐的场景。队列已满或为空时 TryEnQueue 和 TryDeQueue 会立即返回 false，不会阻塞。
+type ConcurrentRingQueue[T any] struct {
+	_          [cacheLineSize]byte
+	enqueuePos atomic.Uint64
+	_          [cacheLineSize - 8]byte
+	dequeuePos atomic.Uint64
+	_          [cacheLineSize - 8]byte
+	mask       uint64
+	cells      []ringCell[T]
+}
+
+// TryEnQueue 尝试将元素放入队尾，如果队列已满，返回 false。
+func (Self *ConcurrentRingQueue[T]) TryEnQueue(val T) bool {
+	pos := Self.enqueuePos.Load()
+	for {
+		cell := &Self.cells[pos&Self.mask]
+		seq := cell.sequence.Load()
+		switch diff := int64(seq - pos); {
+		case diff == 0:
+			// 槽位空闲，抢占当前位置
+			if Self.enqueuePos.CompareAndSwap(pos, pos+1) {
+				cell.val = val
+				cell.sequence.Store(pos + 1)
+				return true
+			}
+			pos = Self.enqueuePos.Load()
+		case diff < 0:
+			// 槽位中的元素还没有被消费，队列已满
+			return false
+		default:
+			// 其他生产者已经抢占了该位置，重新读取
+			pos = Self.enqueuePos.Load()
+		}
+	}
+}
+
+// TryDeQueue 尝试移除并返回队首元素，如果队列为空，第二个返回值为 false。
+func (Self *ConcurrentRingQueue[T]) TryDeQueue() (T, bool) {
+	pos := Self.dequeuePos.Load()
+	for {
+		cell := &Self.cells[pos&Self.mask]
+		seq := cell.sequence.Load()
+		switch diff := int64(seq - (pos + 1)); {
+		case diff == 0:
+			// 槽位中有元素，抢占当前位置
+			if Self.dequeuePos.CompareAndSwap(pos, pos+1) {
+				val := cell.val
+				cell.val = genericgo.Zero[T]()
+				// 该槽位下一轮可以写入位置为 pos+len(cells) 的元素
+				cell.sequence.Store(pos + Self.mask + 1)
+				return val, true
+			}
+			pos = Self.dequeuePos.Load()
+		case diff < 0:
+			// 槽位还没有被写入，队列为空
+			return genericgo.Zero[T](), false
+		default:
+			// 其他消费者已经抢占了该位置，重新读取
+			pos = Self.dequeuePos.Load()
+		}
+	}
+}
+
+// Len 返回队列中元素的数量。并发修改时该值只是一个近似值。
+func (Self *ConcurrentRingQueue[T]) Len() int {
+	// 先读取出队位置，保证差值不会为负数
+	dequeuePos := Self.dequeuePos.Load()
+	enqueuePos := Self.enqueuePos.Load()
+	// 两次读取之间可能发生了出队和入队，差值可能超过容量
+	return min(int(enqueuePos-dequeuePos), len(Self.cells))
+}
+
+// Cap 返回队列的容量。
+func (Self *ConcurrentRingQueue[T]) Cap() int {
+	return len(Self.cells)
+}
+
+// NewConcurrentRingQueue 创建一个新的 ConcurrentRingQueue。
+// 为了使用位运算计算槽位，容量会向上取整为2的幂，且最小为2。
+func NewConcurrentRingQueue[T any](capacity int) *ConcurrentRingQueue[T] {
+	size := 2
+	for size < capacity {
+		size <<= 1
+	}
+	res := &ConcurrentRingQueue[T]{
+		mask:  uint64(size - 1),
+		cells: make([]ringCell[T], size),
+	}
+	for i := range res.cells {
+		res.cells[i].sequence.Store(uint64(i))
+	}
+	return res
+}
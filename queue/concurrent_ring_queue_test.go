@@ -0,0 +1,186 @@
+// Package queue
+/**
+* @Project : GenericGo
+* @File    : concurrent_ring_queue_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/4 11:05
+**/
+
+package queue
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/HJH0924/GenericGo/list"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConcurrentRingQueue(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		wantCap  int
+	}{
+		{
+			name:     "Test zero capacity",
+			capacity: 0,
+			wantCap:  2,
+		},
+		{
+			name:     "Test power of two",
+			capacity: 8,
+			wantCap:  8,
+		},
+		{
+			name:     "Test round up",
+			capacity: 9,
+			wantCap:  16,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q := NewConcurrentRingQueue[int](test.capacity)
+			assert.Equal(t, test.wantCap, q.Cap())
+			assert.Equal(t, 0, q.Len())
+		})
+	}
+}
+
+func TestConcurrentRingQueue_TryEnQueueTryDeQueue(t *testing.T) {
+	q := NewConcurrentRingQueue[int](4)
+	_, ok := q.TryDeQueue()
+	assert.False(t, ok)
+
+	// 多轮写满再读空，覆盖槽位序号的回绕
+	for round := 0; round < 3; round++ {
+		for i := 0; i < 4; i++ {
+			require.True(t, q.TryEnQueue(round*10+i))
+		}
+		assert.False(t, q.TryEnQueue(100))
+		assert.Equal(t, 4, q.Len())
+
+		for i := 0; i < 4; i++ {
+			val, ok := q.TryDeQueue()
+			require.True(t, ok)
+			assert.Equal(t, round*10+i, val)
+		}
+		_, ok = q.TryDeQueue()
+		assert.False(t, ok)
+		assert.Equal(t, 0, q.Len())
+	}
+}
+
+func TestConcurrentRingQueue_Concurrent(t *testing.T) {
+	const (
+		producers = 4
+		consumers = 4
+		perWorker = 10000
+	)
+	q := NewConcurrentRingQueue[int](64)
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				for !q.TryEnQueue(p*perWorker + i) {
+					runtime.Gosched()
+				}
+			}
+		}(p)
+	}
+
+	results := make([][]int, consumers)
+	for c := 0; c < consumers; c++ {
+		wg.Add(1)
+		go func(c int) {
+			defer wg.Done()
+			got := make([]int, 0, perWorker)
+			for len(got) < perWorker {
+				val, ok := q.TryDeQueue()
+				if !ok {
+					runtime.Gosched()
+					continue
+				}
+				got = append(got, val)
+			}
+			results[c] = got
+		}(c)
+	}
+	wg.Wait()
+
+	all := make([]int, 0, producers*perWorker)
+	for _, got := range results {
+		// 同一个生产者的元素在每个消费者看来都是有序的
+		last := make(map[int]int)
+		for _, val := range got {
+			p := val / perWorker
+			if prev, ok := last[p]; ok {
+				assert.Less(t, prev, val)
+			}
+			last[p] = val
+		}
+		all = append(all, got...)
+	}
+	sort.Ints(all)
+	assert.Equal(t, makeRange(0, producers*perWorker), all)
+	assert.Equal(t, 0, q.Len())
+}
+
+// 以下基准测试比较无锁队列与基于互斥锁的并发容器，可以通过 make bench 运行
+
+func BenchmarkConcurrentRingQueue(b *testing.B) {
+	q := NewConcurrentRingQueue[int](1024)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			for !q.TryEnQueue(1) {
+				runtime.Gosched()
+			}
+			for {
+				if _, ok := q.TryDeQueue(); ok {
+					break
+				}
+				runtime.Gosched()
+			}
+		}
+	})
+}
+
+func BenchmarkConcurrentPriorityQueue(b *testing.B) {
+	q := NewConcurrentPriorityQueue[int](1024, getIntComparator())
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			for q.EnQueue(1) != nil {
+				runtime.Gosched()
+			}
+			for {
+				if _, err := q.DeQueue(); err == nil {
+					break
+				}
+				runtime.Gosched()
+			}
+		}
+	})
+}
+
+func BenchmarkConcurrentList(b *testing.B) {
+	l := list.NewConcurrentListOf[int](list.NewLinkedList[int]())
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			l.Append(1)
+			for {
+				if _, err := l.Delete(0); err == nil {
+					break
+				}
+				runtime.Gosched()
+			}
+		}
+	})
+}
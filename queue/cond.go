@@ -0,0 +1,68 @@
+// Package queue
+/**
+* @Project : GenericGo
+* @File    : cond.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/26 09:48
+**/
+
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// cond 是一个支持 context 的条件变量
+// sync.Cond 的 Wait 无法被取消，所以这里用一个会被关闭的 channel 来通知等待者：
+// broadcast 关闭当前的 channel 唤醒所有等待者，并换上一个新的 channel 给后续的等待者使用
+type cond struct {
+	locker sync.Locker
+	ch     chan struct{}
+}
+
+// wait 释放锁并等待 broadcast 或者 ctx 结束，返回前会重新持有锁
+// 调用方需要持有锁，并在返回后重新检查等待的条件
+func (c *cond) wait(ctx context.Context) error {
+	ch := c.ch
+	c.locker.Unlock()
+	defer c.locker.Lock()
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// waitFor 与 wait 类似，但最多等待 timeout，超时返回 nil，调用方需要重新检查等待的条件
+func (c *cond) waitFor(ctx context.Context, timeout time.Duration) error {
+	ch := c.ch
+	c.locker.Unlock()
+	defer c.locker.Lock()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-ch:
+		return nil
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// broadcast 唤醒所有等待者，调用方需要持有锁
+func (c *cond) broadcast() {
+	close(c.ch)
+	c.ch = make(chan struct{})
+}
+
+func newCond(locker sync.Locker) *cond {
+	return &cond{
+		locker: locker,
+		ch:     make(chan struct{}),
+	}
+}
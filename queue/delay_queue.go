@@ -0,0 +1,122 @@
+// Package queue
+/**
+* @Project : GenericGo
+* @File    : delay_queue.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/27 09:30
+**/
+
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	genericgo "github.com/HJH0924/GenericGo"
+)
+
+var (
+	_ BlockingQueue[Delayable] = &DelayQueue[Delayable]{}
+)
+
+// Delayable 是可以放入 DelayQueue 的元素，Deadline 返回元素可以出队的时间
+// 同一个元素的 Deadline 在入队后不能改变
+type Delayable interface {
+	Deadline() time.Time
+}
+
+// DelayQueue 并发安全的延时队列，底层使用按照 Deadline 排序的 PriorityQueue
+// DeQueue 会阻塞直到最早的元素到期，入队一个更早到期的元素会唤醒正在等待的 DeQueue。
+// 当 capacity > 0 时，为有界队列，队列已满时 EnQueue 会阻塞。
+type DelayQueue[T Delayable] struct {
+	pq            *PriorityQueue[T]
+	mutex         sync.Mutex
+	enqueueSignal *cond // 有元素入队时广播
+	dequeueSignal *cond // 有元素出队时广播
+}
+
+// EnQueue 将元素放入队列，如果队列已满，则阻塞直到有空位或者 ctx 结束。
+func (Self *DelayQueue[T]) EnQueue(ctx context.Context, val T) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	for Self.pq.IsFull() {
+		if err := Self.dequeueSignal.wait(ctx); err != nil {
+			return err
+		}
+	}
+	if err := Self.pq.EnQueue(val); err != nil {
+		return err
+	}
+	// 新元素可能比原来的队首更早到期，唤醒等待者重新计算等待时间
+	Self.enqueueSignal.broadcast()
+	return nil
+}
+
+// DeQueue 移除并返回最早到期的元素，如果队列为空或者队首元素尚未到期，
+// 则阻塞直到有元素到期或者 ctx 结束。
+func (Self *DelayQueue[T]) DeQueue(ctx context.Context) (T, error) {
+	if ctx.Err() != nil {
+		return genericgo.Zero[T](), ctx.Err()
+	}
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	for {
+		head, err := Self.pq.Peek()
+		if err != nil {
+			// 队列为空，等待新元素入队
+			if err = Self.enqueueSignal.wait(ctx); err != nil {
+				return genericgo.Zero[T](), err
+			}
+			continue
+		}
+
+		delay := time.Until(head.Deadline())
+		if delay <= 0 {
+			val, err := Self.pq.DeQueue()
+			if err != nil {
+				return genericgo.Zero[T](), err
+			}
+			Self.dequeueSignal.broadcast()
+			return val, nil
+		}
+
+		// 等待队首元素到期，期间如果有新元素入队，会被提前唤醒并重新检查队首（新元素可能更早到期）。
+		// 如果队首被其他协程取走，新的队首不会早于原来的队首到期，因此等待到期后再重新检查即可
+		if err = Self.enqueueSignal.waitFor(ctx, delay); err != nil {
+			return genericgo.Zero[T](), err
+		}
+	}
+}
+
+// Len 返回队列中元素的数量，包括尚未到期的元素。
+func (Self *DelayQueue[T]) Len() int {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	return Self.pq.Len()
+}
+
+// IsEmpty 返回队列是否为空。
+func (Self *DelayQueue[T]) IsEmpty() bool {
+	Self.mutex.Lock()
+	defer Self.mutex.Unlock()
+	return Self.pq.IsEmpty()
+}
+
+// NewDelayQueue 创建一个新的 DelayQueue。
+// 当 capacity <= 0 时，视为无界队列，EnQueue 永远不会阻塞。
+func NewDelayQueue[T Delayable](capacity int) *DelayQueue[T] {
+	res := &DelayQueue[T]{
+		// PriorityQueue 是大根堆，越早到期的元素优先级越高
+		pq: NewPriorityQueue[T](capacity, func(l, r T) int {
+			return r.Deadline().Compare(l.Deadline())
+		}),
+	}
+	res.enqueueSignal = newCond(&res.mutex)
+	res.dequeueSignal = newCond(&res.mutex)
+	return res
+}
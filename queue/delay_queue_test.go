@@ -0,0 +1,202 @@
+// Package queue
+/**
+* @Project : GenericGo
+* @File    : delay_queue_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/27 10:26
+**/
+
+package queue
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type delayElem struct {
+	deadline time.Time
+	val      int
+}
+
+func (d delayElem) Deadline() time.Time {
+	return d.deadline
+}
+
+func newDelayElem(delay time.Duration, val int) delayElem {
+	return delayElem{
+		deadline: time.Now().Add(delay),
+		val:      val,
+	}
+}
+
+func TestDelayQueue_DeQueue(t *testing.T) {
+	tests := []struct {
+		name     string
+		delays   []time.Duration
+		timeout  time.Duration
+		wantVal  int
+		wantErr  error
+		minDelay time.Duration
+	}{
+		{
+			name:    "Test empty queue",
+			timeout: 50 * time.Millisecond,
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name:    "Test expired element",
+			delays:  []time.Duration{-time.Second},
+			timeout: 50 * time.Millisecond,
+			wantVal: -1000,
+		},
+		{
+			name:    "Test not yet expired before timeout",
+			delays:  []time.Duration{time.Second},
+			timeout: 50 * time.Millisecond,
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name:     "Test wait until earliest element expires",
+			delays:   []time.Duration{300 * time.Millisecond, 100 * time.Millisecond, 200 * time.Millisecond},
+			timeout:  time.Second,
+			wantVal:  100,
+			minDelay: 80 * time.Millisecond,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q := NewDelayQueue[delayElem](0)
+			// 元素的值为其延时的毫秒数
+			for _, delay := range test.delays {
+				require.NoError(t, q.EnQueue(context.Background(), newDelayElem(delay, int(delay/time.Millisecond))))
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), test.timeout)
+			defer cancel()
+			start := time.Now()
+			elem, err := q.DeQueue(ctx)
+			assert.Equal(t, test.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, test.wantVal, elem.val)
+			assert.GreaterOrEqual(t, time.Since(start), test.minDelay)
+			assert.False(t, time.Now().Before(elem.deadline))
+		})
+	}
+}
+
+func TestDelayQueue_Order(t *testing.T) {
+	q := NewDelayQueue[delayElem](0)
+	delays := []int{50, 10, 40, 20, 30}
+	for _, d := range delays {
+		require.NoError(t, q.EnQueue(context.Background(), newDelayElem(time.Duration(d)*time.Millisecond, d)))
+	}
+	assert.Equal(t, len(delays), q.Len())
+
+	res := make([]int, 0, len(delays))
+	for !q.IsEmpty() {
+		elem, err := q.DeQueue(context.Background())
+		require.NoError(t, err)
+		res = append(res, elem.val)
+	}
+	assert.Equal(t, []int{10, 20, 30, 40, 50}, res)
+}
+
+func TestDelayQueue_EarlierEnQueueWakesWaiter(t *testing.T) {
+	q := NewDelayQueue[delayElem](0)
+	require.NoError(t, q.EnQueue(context.Background(), newDelayElem(time.Hour, 1)))
+
+	done := make(chan delayElem)
+	go func() {
+		elem, err := q.DeQueue(context.Background())
+		assert.NoError(t, err)
+		done <- elem
+	}()
+	// 等待消费者开始等待一小时后到期的元素
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, q.EnQueue(context.Background(), newDelayElem(30*time.Millisecond, 2)))
+
+	select {
+	case elem := <-done:
+		assert.Equal(t, 2, elem.val)
+	case <-time.After(time.Second):
+		t.Fatal("DeQueue 没有被更早到期的元素唤醒")
+	}
+	assert.Equal(t, 1, q.Len())
+}
+
+func TestDelayQueue_EnQueueBlocksWhenFull(t *testing.T) {
+	q := NewDelayQueue[delayElem](1)
+	require.NoError(t, q.EnQueue(context.Background(), newDelayElem(0, 1)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, q.EnQueue(ctx, newDelayElem(0, 2)))
+
+	done := make(chan struct{})
+	go func() {
+		assert.NoError(t, q.EnQueue(context.Background(), newDelayElem(0, 2)))
+		close(done)
+	}()
+	elem, err := q.DeQueue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, elem.val)
+	<-done
+	assert.Equal(t, 1, q.Len())
+}
+
+func TestDelayQueue_Concurrent(t *testing.T) {
+	const (
+		producers = 4
+		consumers = 4
+		perWorker = 100
+	)
+	q := NewDelayQueue[delayElem](16)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				delay := time.Duration(i%5) * time.Millisecond
+				assert.NoError(t, q.EnQueue(ctx, newDelayElem(delay, p*perWorker+i)))
+			}
+		}(p)
+	}
+
+	var (
+		mutex sync.Mutex
+		got   = make([]int, 0, producers*perWorker)
+	)
+	for c := 0; c < consumers; c++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				elem, err := q.DeQueue(ctx)
+				if !assert.NoError(t, err) {
+					return
+				}
+				assert.False(t, time.Now().Before(elem.deadline))
+				mutex.Lock()
+				got = append(got, elem.val)
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	sort.Ints(got)
+	assert.Equal(t, makeRange(0, producers*perWorker), got)
+}
@@ -0,0 +1,261 @@
+// Package queue
+/**
+* @Project : GenericGo
+* @File    : deque.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/3 09:45
+**/
+
+package queue
+
+import (
+	genericgo "github.com/HJH0924/GenericGo"
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/list"
+	"github.com/HJH0924/GenericGo/slice"
+)
+
+var (
+	_ list.List[any] = &Deque[any]{}
+)
+
+// Deque 是基于环形缓冲区实现的双端队列，在两端插入和删除元素的时间复杂度都是 O(1)，
+// 按下标访问元素的时间复杂度也是 O(1)。
+// 缓冲区已满时扩容为原来的两倍，删除元素后按照 slice.ShrinkSlice 的策略缩容。
+// Deque 同时实现了 list.List，可以使用 list.ConcurrentList 包装成并发安全的版本。
+type Deque[T any] struct {
+	vals  []T // 环形缓冲区，len(vals) 即缓冲区的大小
+	head  int // 第一个元素在缓冲区中的下标
+	count int // 元素的数量
+}
+
+// PushFront 在队头插入一个元素。
+func (Self *Deque[T]) PushFront(val T) {
+	Self.growIfFull()
+	Self.head = Self.physical(-1)
+	Self.vals[Self.head] = val
+	Self.count++
+}
+
+// PushBack 在队尾插入一个元素。
+func (Self *Deque[T]) PushBack(val T) {
+	Self.growIfFull()
+	Self.vals[Self.physical(Self.count)] = val
+	Self.count++
+}
+
+// PopFront 移除并返回队头的元素。如果队列为空，返回错误。
+func (Self *Deque[T]) PopFront() (T, error) {
+	if Self.count == 0 {
+		return genericgo.Zero[T](), errs.NewErrEmptyQueue()
+	}
+	val := Self.vals[Self.head]
+	// 释放对元素的引用，方便 GC 回收
+	Self.vals[Self.head] = genericgo.Zero[T]()
+	Self.head = Self.physical(1)
+	Self.count--
+	Self.shrink()
+	return val, nil
+}
+
+// PopBack 移除并返回队尾的元素。如果队列为空，返回错误。
+func (Self *Deque[T]) PopBack() (T, error) {
+	if Self.count == 0 {
+		return genericgo.Zero[T](), errs.NewErrEmptyQueue()
+	}
+	tail := Self.physical(Self.count - 1)
+	val := Self.vals[tail]
+	Self.vals[tail] = genericgo.Zero[T]()
+	Self.count--
+	Self.shrink()
+	return val, nil
+}
+
+// Front 返回队头的元素，但不移除它。如果队列为空，返回错误。
+func (Self *Deque[T]) Front() (T, error) {
+	if Self.count == 0 {
+		return genericgo.Zero[T](), errs.NewErrEmptyQueue()
+	}
+	return Self.vals[Self.head], nil
+}
+
+// Back 返回队尾的元素，但不移除它。如果队列为空，返回错误。
+func (Self *Deque[T]) Back() (T, error) {
+	if Self.count == 0 {
+		return genericgo.Zero[T](), errs.NewErrEmptyQueue()
+	}
+	return Self.vals[Self.physical(Self.count-1)], nil
+}
+
+// Append 在 Deque 末尾追加一个或多个元素。
+func (Self *Deque[T]) Append(vals ...T) {
+	for _, val := range vals {
+		Self.PushBack(val)
+	}
+}
+
+// Add 在特定下标处增加一个新元素。
+// 如果下标超出合法范围，返回错误。
+// 如果 idx 等于 Deque 长度，则表示往 Deque 末端增加元素。
+// 插入位置之前和之后的元素中，数量较少的一侧会被移动。
+func (Self *Deque[T]) Add(idx int, val T) error {
+	if idx < 0 || idx > Self.count {
+		return errs.NewErrIndexOutOfRange(Self.count, idx)
+	}
+	Self.growIfFull()
+	if idx < Self.count/2 {
+		// 前 idx 个元素整体向前移动一位
+		Self.head = Self.physical(-1)
+		for i := 0; i < idx; i++ {
+			Self.vals[Self.physical(i)] = Self.vals[Self.physical(i+1)]
+		}
+	} else {
+		// 下标 idx 及之后的元素整体向后移动一位
+		for i := Self.count; i > idx; i-- {
+			Self.vals[Self.physical(i)] = Self.vals[Self.physical(i-1)]
+		}
+	}
+	Self.vals[Self.physical(idx)] = val
+	Self.count++
+	return nil
+}
+
+// Delete 删除指定下标的元素，并返回被删除的元素。
+// 如果下标超出合法范围，返回错误。
+// 删除位置之前和之后的元素中，数量较少的一侧会被移动，可能会发生缩容。
+func (Self *Deque[T]) Delete(idx int) (T, error) {
+	if idx < 0 || idx >= Self.count {
+		return genericgo.Zero[T](), errs.NewErrIndexOutOfRange(Self.count, idx)
+	}
+	val := Self.vals[Self.physical(idx)]
+	if idx < Self.count/2 {
+		// 前 idx 个元素整体向后移动一位
+		for i := idx; i > 0; i-- {
+			Self.vals[Self.physical(i)] = Self.vals[Self.physical(i-1)]
+		}
+		Self.vals[Self.head] = genericgo.Zero[T]()
+		Self.head = Self.physical(1)
+	} else {
+		// 下标 idx 之后的元素整体向前移动一位
+		for i := idx; i < Self.count-1; i++ {
+			Self.vals[Self.physical(i)] = Self.vals[Self.physical(i+1)]
+		}
+		Self.vals[Self.physical(Self.count-1)] = genericgo.Zero[T]()
+	}
+	Self.count--
+	Self.shrink()
+	return val, nil
+}
+
+// Set 重置指定下标位置的元素为 val。
+// 如果下标超出合法范围，返回错误。
+func (Self *Deque[T]) Set(idx int, val T) error {
+	if idx < 0 || idx >= Self.count {
+		return errs.NewErrIndexOutOfRange(Self.count, idx)
+	}
+	Self.vals[Self.physical(idx)] = val
+	return nil
+}
+
+// Get 返回对应下标的元素，下标0为队头。
+// 如果下标超出合法范围，返回错误。
+func (Self *Deque[T]) Get(idx int) (T, error) {
+	if idx < 0 || idx >= Self.count {
+		return genericgo.Zero[T](), errs.NewErrIndexOutOfRange(Self.count, idx)
+	}
+	return Self.vals[Self.physical(idx)], nil
+}
+
+// Len 返回 Deque 中元素的数量。
+func (Self *Deque[T]) Len() int {
+	return Self.count
+}
+
+// Cap 返回 Deque 缓冲区的大小。
+func (Self *Deque[T]) Cap() int {
+	return len(Self.vals)
+}
+
+// IsEmpty 返回 Deque 是否为空。
+func (Self *Deque[T]) IsEmpty() bool {
+	return Self.count == 0
+}
+
+// Range 从队头到队尾遍历 Deque 的所有元素，并使用给定的函数访问每个元素。
+func (Self *Deque[T]) Range(onVal func(idx int, val T) error) error {
+	for i := 0; i < Self.count; i++ {
+		if err := onVal(i, Self.vals[Self.physical(i)]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AsSlice 将 Deque 从队头到队尾转化为一个新切片，即使 Deque 为空，也返回一个长度和容量都为0的切片。
+func (Self *Deque[T]) AsSlice() []T {
+	res := make([]T, Self.count)
+	Self.copyTo(res)
+	return res
+}
+
+// physical 返回下标为 idx 的元素在缓冲区中的位置，idx 可以为 -1 表示队头之前的位置
+func (Self *Deque[T]) physical(idx int) int {
+	n := len(Self.vals)
+	return ((Self.head+idx)%n + n) % n
+}
+
+// copyTo 从队头到队尾将元素复制到 dst 中，dst 的长度不能小于 count
+func (Self *Deque[T]) copyTo(dst []T) {
+	end := Self.head + Self.count
+	if end <= len(Self.vals) {
+		copy(dst, Self.vals[Self.head:end])
+		return
+	}
+	n := copy(dst, Self.vals[Self.head:])
+	copy(dst[n:], Self.vals[:end-len(Self.vals)])
+}
+
+// growIfFull 缓冲区已满时扩容为原来的两倍
+func (Self *Deque[T]) growIfFull() {
+	if Self.count < len(Self.vals) {
+		return
+	}
+	newVals := make([]T, 2*len(Self.vals))
+	Self.copyTo(newVals)
+	Self.vals = newVals
+	Self.head = 0
+}
+
+// shrink 使用 slice.ShrinkSlice 的缩容策略决定是否需要缩小缓冲区
+func (Self *Deque[T]) shrink() {
+	// 只借用 ShrinkSlice 计算出的容量，元素由 copyTo 重新按顺序复制
+	newVals := slice.ShrinkSlice(Self.vals[:Self.count])
+	if cap(newVals) >= len(Self.vals) {
+		return
+	}
+	newVals = newVals[:cap(newVals)]
+	Self.copyTo(newVals)
+	Self.vals = newVals
+	Self.head = 0
+}
+
+// NewDeque 创建一个缓冲区大小为 capacity 的 Deque，当 capacity <= 0 时，使用默认值64。
+func NewDeque[T any](capacity int) *Deque[T] {
+	const (
+		DefaultInitialCap = 64
+	)
+	if capacity <= 0 {
+		capacity = DefaultInitialCap
+	}
+	return &Deque[T]{
+		vals: make([]T, capacity),
+	}
+}
+
+// NewDequeOf 创建一个 Deque，并使用提供的切片 vals 作为初始元素，vals[0] 为队头。
+func NewDequeOf[T any](vals []T) *Deque[T] {
+	res := NewDeque[T](len(vals))
+	res.Append(vals...)
+	return res
+}
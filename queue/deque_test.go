@@ -0,0 +1,297 @@
+// Package queue
+/**
+* @Project : GenericGo
+* @File    : deque_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/3 11:20
+**/
+
+package queue
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/list"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeque_PushPop(t *testing.T) {
+	d := NewDeque[int](2)
+	d.PushBack(2)
+	d.PushFront(1)
+	d.PushBack(3)
+	d.PushFront(0)
+	assert.Equal(t, []int{0, 1, 2, 3}, d.AsSlice())
+
+	front, err := d.Front()
+	require.NoError(t, err)
+	assert.Equal(t, 0, front)
+	back, err := d.Back()
+	require.NoError(t, err)
+	assert.Equal(t, 3, back)
+
+	val, err := d.PopFront()
+	require.NoError(t, err)
+	assert.Equal(t, 0, val)
+	val, err = d.PopBack()
+	require.NoError(t, err)
+	assert.Equal(t, 3, val)
+	assert.Equal(t, []int{1, 2}, d.AsSlice())
+}
+
+func TestDeque_Empty(t *testing.T) {
+	d := NewDeque[int](0)
+	assert.True(t, d.IsEmpty())
+	assert.Equal(t, []int{}, d.AsSlice())
+
+	_, err := d.PopFront()
+	assert.Equal(t, errs.NewErrEmptyQueue(), err)
+	_, err = d.PopBack()
+	assert.Equal(t, errs.NewErrEmptyQueue(), err)
+	_, err = d.Front()
+	assert.Equal(t, errs.NewErrEmptyQueue(), err)
+	_, err = d.Back()
+	assert.Equal(t, errs.NewErrEmptyQueue(), err)
+}
+
+func TestDeque_Add(t *testing.T) {
+	tests := []struct {
+		name      string
+		vals      []int
+		idx       int
+		val       int
+		wantSlice []int
+		wantErr   error
+	}{
+		{
+			name:      "Add at head",
+			vals:      []int{1, 2, 3},
+			idx:       0,
+			val:       0,
+			wantSlice: []int{0, 1, 2, 3},
+		},
+		{
+			name:      "Add in front half",
+			vals:      []int{1, 2, 3, 4, 5},
+			idx:       1,
+			val:       100,
+			wantSlice: []int{1, 100, 2, 3, 4, 5},
+		},
+		{
+			name:      "Add in back half",
+			vals:      []int{1, 2, 3, 4, 5},
+			idx:       4,
+			val:       100,
+			wantSlice: []int{1, 2, 3, 4, 100, 5},
+		},
+		{
+			name:      "Add at tail",
+			vals:      []int{1, 2, 3},
+			idx:       3,
+			val:       4,
+			wantSlice: []int{1, 2, 3, 4},
+		},
+		{
+			name:      "Add to full buffer",
+			vals:      []int{1, 2},
+			idx:       1,
+			val:       100,
+			wantSlice: []int{1, 100, 2},
+		},
+		{
+			name:      "Index out of range",
+			vals:      []int{1, 2, 3},
+			idx:       4,
+			wantSlice: []int{1, 2, 3},
+			wantErr:   errs.NewErrIndexOutOfRange(3, 4),
+		},
+		{
+			name:      "Negative index",
+			vals:      []int{1, 2, 3},
+			idx:       -1,
+			wantSlice: []int{1, 2, 3},
+			wantErr:   errs.NewErrIndexOutOfRange(3, -1),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := NewDequeOf(test.vals)
+			err := d.Add(test.idx, test.val)
+			assert.Equal(t, test.wantErr, err)
+			assert.Equal(t, test.wantSlice, d.AsSlice())
+		})
+	}
+}
+
+func TestDeque_Delete(t *testing.T) {
+	tests := []struct {
+		name      string
+		vals      []int
+		idx       int
+		wantVal   int
+		wantSlice []int
+		wantErr   error
+	}{
+		{
+			name:      "Delete head",
+			vals:      []int{1, 2, 3},
+			idx:       0,
+			wantVal:   1,
+			wantSlice: []int{2, 3},
+		},
+		{
+			name:      "Delete in front half",
+			vals:      []int{1, 2, 3, 4, 5},
+			idx:       1,
+			wantVal:   2,
+			wantSlice: []int{1, 3, 4, 5},
+		},
+		{
+			name:      "Delete in back half",
+			vals:      []int{1, 2, 3, 4, 5},
+			idx:       3,
+			wantVal:   4,
+			wantSlice: []int{1, 2, 3, 5},
+		},
+		{
+			name:      "Delete tail",
+			vals:      []int{1, 2, 3},
+			idx:       2,
+			wantVal:   3,
+			wantSlice: []int{1, 2},
+		},
+		{
+			name:      "Index out of range",
+			vals:      []int{1, 2, 3},
+			idx:       3,
+			wantSlice: []int{1, 2, 3},
+			wantErr:   errs.NewErrIndexOutOfRange(3, 3),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := NewDequeOf(test.vals)
+			val, err := d.Delete(test.idx)
+			assert.Equal(t, test.wantErr, err)
+			assert.Equal(t, test.wantVal, val)
+			assert.Equal(t, test.wantSlice, d.AsSlice())
+		})
+	}
+}
+
+func TestDeque_GetSet(t *testing.T) {
+	d := NewDeque[int](4)
+	// 让元素跨越缓冲区的末尾
+	d.PushBack(2)
+	d.PushBack(3)
+	d.PushFront(1)
+	d.PushFront(0)
+
+	for i := 0; i < 4; i++ {
+		val, err := d.Get(i)
+		require.NoError(t, err)
+		assert.Equal(t, i, val)
+		require.NoError(t, d.Set(i, i*10))
+	}
+	assert.Equal(t, []int{0, 10, 20, 30}, d.AsSlice())
+
+	_, err := d.Get(4)
+	assert.Equal(t, errs.NewErrIndexOutOfRange(4, 4), err)
+	assert.Equal(t, errs.NewErrIndexOutOfRange(4, -1), d.Set(-1, 0))
+
+	res := make([]int, 0, d.Len())
+	err = d.Range(func(idx int, val int) error {
+		assert.Equal(t, idx*10, val)
+		res = append(res, val)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, d.AsSlice(), res)
+}
+
+func TestDeque_GrowAndShrink(t *testing.T) {
+	d := NewDeque[int](0)
+	for i := 0; i < 2048; i++ {
+		d.PushFront(-i - 1)
+		d.PushBack(i)
+	}
+	grownCap := d.Cap()
+	assert.GreaterOrEqual(t, grownCap, 4096)
+
+	for i := 0; i < 2000; i++ {
+		_, err := d.PopFront()
+		require.NoError(t, err)
+		_, err = d.PopBack()
+		require.NoError(t, err)
+	}
+	assert.Less(t, d.Cap(), grownCap)
+	want := make([]int, 0, 96)
+	for i := -48; i < 48; i++ {
+		want = append(want, i)
+	}
+	assert.Equal(t, want, d.AsSlice())
+}
+
+func TestDeque_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(2024))
+	d := NewDeque[int](1)
+	model := make([]int, 0)
+	for i := 0; i < 5000; i++ {
+		switch op := r.Intn(6); op {
+		case 0:
+			d.PushFront(i)
+			model = append([]int{i}, model...)
+		case 1:
+			d.PushBack(i)
+			model = append(model, i)
+		case 2:
+			idx := r.Intn(len(model) + 1)
+			require.NoError(t, d.Add(idx, i))
+			model = append(model[:idx], append([]int{i}, model[idx:]...)...)
+		case 3, 4:
+			if len(model) == 0 {
+				continue
+			}
+			idx := r.Intn(len(model))
+			val, err := d.Delete(idx)
+			require.NoError(t, err)
+			require.Equal(t, model[idx], val)
+			model = append(model[:idx], model[idx+1:]...)
+		default:
+			if len(model) == 0 {
+				continue
+			}
+			val, err := d.PopFront()
+			require.NoError(t, err)
+			require.Equal(t, model[0], val)
+			model = model[1:]
+		}
+		require.Equal(t, len(model), d.Len())
+	}
+	assert.Equal(t, model, d.AsSlice())
+}
+
+func TestDeque_ConcurrentList(t *testing.T) {
+	cl := list.NewConcurrentListOf[int](NewDeque[int](0))
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				cl.Append(i)
+				_ = cl.Add(0, i)
+				_, _ = cl.Get(0)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 8*500*2, cl.Len())
+}
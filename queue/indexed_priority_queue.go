@@ -0,0 +1,227 @@
+// Package queue
+/**
+* @Project : GenericGo
+* @File    : indexed_priority_queue.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/29 09:40
+**/
+
+package queue
+
+import (
+	genericgo "github.com/HJH0924/GenericGo"
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/slice"
+)
+
+// Handle 是元素在 IndexedPriorityQueue 中的句柄，EnQueue 时返回
+// 通过句柄可以在 O(log n) 的时间内修改元素的优先级或者删除元素
+type Handle[T any] struct {
+	val   T
+	index int                      // 元素在堆中的下标，0 表示元素已经不在队列中
+	owner *IndexedPriorityQueue[T] // 元素所属的队列
+}
+
+// Value 返回句柄对应的元素。
+func (h *Handle[T]) Value() T {
+	return h.val
+}
+
+// IndexedPriorityQueue 是一个基于大根堆的索引优先级队列
+// 与 PriorityQueue 不同，EnQueue 会返回元素的句柄，之后可以通过句柄修改元素的优先级或者删除元素，
+// 适用于 Dijkstra 算法中的松弛操作、取消已经调度的任务等场景。
+// 当 capacity <= 0 时，为无界队列；当 capacity > 0 时，为有界队列。
+// 与 PriorityQueue 一样，底层切片的第一个元素（索引0）留空，实际元素从索引1开始。
+type IndexedPriorityQueue[T any] struct {
+	compare  genericgo.Comparator[T]
+	capacity int
+	handles  []*Handle[T]
+}
+
+// Len 返回队列中元素的数量。
+func (Self *IndexedPriorityQueue[T]) Len() int {
+	return len(Self.handles) - 1
+}
+
+// Cap 返回队列的容量。
+func (Self *IndexedPriorityQueue[T]) Cap() int {
+	return Self.capacity
+}
+
+// IsBoundLess 返回队列是否无界，即容量是否小于或等于0。
+func (Self *IndexedPriorityQueue[T]) IsBoundLess() bool {
+	return Self.capacity <= 0
+}
+
+// IsFull 返回队列是否已满。
+func (Self *IndexedPriorityQueue[T]) IsFull() bool {
+	return Self.capacity > 0 && Self.Len() == Self.capacity
+}
+
+// IsEmpty 返回队列是否为空。
+func (Self *IndexedPriorityQueue[T]) IsEmpty() bool {
+	return len(Self.handles) < 2
+}
+
+// Contains 判断句柄对应的元素是否仍在队列中。
+func (Self *IndexedPriorityQueue[T]) Contains(h *Handle[T]) bool {
+	return h != nil && h.owner == Self && h.index > 0
+}
+
+// Peek 返回队列顶部的元素，但不移除它。如果队列为空，返回错误。
+func (Self *IndexedPriorityQueue[T]) Peek() (T, error) {
+	if Self.IsEmpty() {
+		return genericgo.Zero[T](), errs.NewErrEmptyQueue()
+	}
+	return Self.handles[1].val, nil
+}
+
+// EnQueue 向队列添加一个新元素，并返回该元素的句柄。如果队列已满，返回错误。
+func (Self *IndexedPriorityQueue[T]) EnQueue(val T) (*Handle[T], error) {
+	if Self.IsFull() {
+		return nil, errs.NewErrOutOfCapacity()
+	}
+	h := &Handle[T]{
+		val:   val,
+		index: len(Self.handles),
+		owner: Self,
+	}
+	Self.handles = append(Self.handles, h)
+	Self.up(h.index)
+	return h, nil
+}
+
+// DeQueue 移除并返回优先级最高的元素。如果队列为空，返回错误。
+func (Self *IndexedPriorityQueue[T]) DeQueue() (T, error) {
+	if Self.IsEmpty() {
+		return genericgo.Zero[T](), errs.NewErrEmptyQueue()
+	}
+	return Self.removeAt(1), nil
+}
+
+// Update 将句柄对应的元素替换为 val，并根据新的优先级调整其位置。
+// 如果句柄不属于该队列或者元素已经出队，返回错误。
+func (Self *IndexedPriorityQueue[T]) Update(h *Handle[T], val T) error {
+	if !Self.Contains(h) {
+		return errs.NewErrInvalidHandle()
+	}
+	h.val = val
+	Self.fix(h.index)
+	return nil
+}
+
+// Fix 在句柄对应元素的优先级被外部修改之后（例如 T 为指针类型时直接修改了其字段），重新调整其位置。
+// 如果句柄不属于该队列或者元素已经出队，返回错误。
+func (Self *IndexedPriorityQueue[T]) Fix(h *Handle[T]) error {
+	if !Self.Contains(h) {
+		return errs.NewErrInvalidHandle()
+	}
+	Self.fix(h.index)
+	return nil
+}
+
+// Remove 从队列中删除句柄对应的元素，并返回该元素。
+// 如果句柄不属于该队列或者元素已经出队，返回错误。
+func (Self *IndexedPriorityQueue[T]) Remove(h *Handle[T]) (T, error) {
+	if !Self.Contains(h) {
+		return genericgo.Zero[T](), errs.NewErrInvalidHandle()
+	}
+	return Self.removeAt(h.index), nil
+}
+
+// AsSlice 返回队列中元素的切片副本，元素按照堆中的顺序排列，第一个元素为优先级最高的元素。
+func (Self *IndexedPriorityQueue[T]) AsSlice() []T {
+	res := make([]T, 0, Self.Len())
+	for _, h := range Self.handles[1:] {
+		res = append(res, h.val)
+	}
+	return res
+}
+
+// removeAt 删除下标为 i 的元素并返回，调用方需要保证 i 合法
+func (Self *IndexedPriorityQueue[T]) removeAt(i int) T {
+	last := Self.Len()
+	removed := Self.handles[i]
+	Self.swap(i, last)
+	Self.handles[last] = nil
+	Self.handles = Self.handles[:last]
+	removed.index = 0
+
+	// 无界队列可能需要缩容
+	if Self.IsBoundLess() {
+		Self.handles = slice.ShrinkSlice(Self.handles)
+	}
+
+	// 被换到 i 处的元素可能需要上浮或者下沉
+	if i < last {
+		Self.fix(i)
+	}
+	return removed.val
+}
+
+// fix 调整下标为 i 的元素的位置，以维持大根堆的性质
+func (Self *IndexedPriorityQueue[T]) fix(i int) {
+	if !Self.up(i) {
+		Self.down(i)
+	}
+}
+
+// up 将下标为 i 的元素上浮，返回元素是否移动过
+func (Self *IndexedPriorityQueue[T]) up(i int) bool {
+	moved := false
+	for i > 1 {
+		parent := i / 2
+		if Self.compare(Self.handles[i].val, Self.handles[parent].val) <= 0 {
+			break
+		}
+		Self.swap(i, parent)
+		i = parent
+		moved = true
+	}
+	return moved
+}
+
+// down 将下标为 i 的元素下沉
+func (Self *IndexedPriorityQueue[T]) down(i int) {
+	n := Self.Len()
+	for {
+		maxPos := i
+		if left := 2 * i; left <= n && Self.compare(Self.handles[left].val, Self.handles[maxPos].val) > 0 {
+			maxPos = left
+		}
+		if right := 2*i + 1; right <= n && Self.compare(Self.handles[right].val, Self.handles[maxPos].val) > 0 {
+			maxPos = right
+		}
+		if maxPos == i {
+			return
+		}
+		Self.swap(i, maxPos)
+		i = maxPos
+	}
+}
+
+// swap 交换下标为 i 和 j 的元素，并同步更新句柄中记录的下标
+func (Self *IndexedPriorityQueue[T]) swap(i, j int) {
+	Self.handles[i], Self.handles[j] = Self.handles[j], Self.handles[i]
+	Self.handles[i].index = i
+	Self.handles[j].index = j
+}
+
+// NewIndexedPriorityQueue 创建一个新的 IndexedPriorityQueue。
+// 当 capacity <= 0 时，视为无界队列，初始大小使用默认值64。
+func NewIndexedPriorityQueue[T any](capacity int, compare genericgo.Comparator[T]) *IndexedPriorityQueue[T] {
+	const (
+		DefaultInitialCap = 64
+	)
+	initialCap := capacity + 1
+	if capacity <= 0 {
+		capacity = 0
+		initialCap = DefaultInitialCap
+	}
+	return &IndexedPriorityQueue[T]{
+		compare:  compare,
+		capacity: capacity,
+		handles:  make([]*Handle[T], 1, initialCap),
+	}
+}
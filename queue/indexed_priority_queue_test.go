@@ -0,0 +1,239 @@
+// Package queue
+/**
+* @Project : GenericGo
+* @File    : indexed_priority_queue_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/29 11:18
+**/
+
+package queue
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// drainIndexed 依次出队所有元素
+func drainIndexed[T any](t *testing.T, pq *IndexedPriorityQueue[T]) []T {
+	res := make([]T, 0, pq.Len())
+	for !pq.IsEmpty() {
+		val, err := pq.DeQueue()
+		require.NoError(t, err)
+		res = append(res, val)
+	}
+	return res
+}
+
+func TestIndexedPriorityQueue_EnQueue(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		vals     []int
+		wantVals []int
+		wantErr  error
+	}{
+		{
+			name:     "Test unbounded queue",
+			capacity: 0,
+			vals:     []int{3, 1, 4, 1, 5, 9, 2, 6},
+			wantVals: []int{9, 6, 5, 4, 3, 2, 1, 1},
+		},
+		{
+			name:     "Test bounded queue full",
+			capacity: 2,
+			vals:     []int{1, 2, 3},
+			wantVals: []int{2, 1},
+			wantErr:  errs.NewErrOutOfCapacity(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pq := NewIndexedPriorityQueue[int](test.capacity, getIntComparator())
+			var err error
+			for _, val := range test.vals {
+				var h *Handle[int]
+				if h, err = pq.EnQueue(val); err != nil {
+					assert.Nil(t, h)
+					break
+				}
+				assert.Equal(t, val, h.Value())
+			}
+			assert.Equal(t, test.wantErr, err)
+			assert.Equal(t, test.wantVals, drainIndexed(t, pq))
+		})
+	}
+}
+
+func TestIndexedPriorityQueue_Update(t *testing.T) {
+	tests := []struct {
+		name     string
+		vals     []int
+		target   int
+		newVal   int
+		wantVals []int
+	}{
+		{
+			name:     "Test increase priority",
+			vals:     []int{1, 2, 3, 4, 5},
+			target:   0,
+			newVal:   10,
+			wantVals: []int{10, 5, 4, 3, 2},
+		},
+		{
+			name:     "Test decrease priority",
+			vals:     []int{1, 2, 3, 4, 5},
+			target:   4,
+			newVal:   0,
+			wantVals: []int{4, 3, 2, 1, 0},
+		},
+		{
+			name:     "Test same priority",
+			vals:     []int{1, 2, 3},
+			target:   1,
+			newVal:   2,
+			wantVals: []int{3, 2, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pq := NewIndexedPriorityQueue[int](0, getIntComparator())
+			handles := make([]*Handle[int], 0, len(test.vals))
+			for _, val := range test.vals {
+				h, err := pq.EnQueue(val)
+				require.NoError(t, err)
+				handles = append(handles, h)
+			}
+			require.NoError(t, pq.Update(handles[test.target], test.newVal))
+			assert.Equal(t, test.newVal, handles[test.target].Value())
+			assert.Equal(t, test.wantVals, drainIndexed(t, pq))
+		})
+	}
+}
+
+func TestIndexedPriorityQueue_Fix(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+	pq := NewIndexedPriorityQueue[*task](0, func(l, r *task) int {
+		return l.priority - r.priority
+	})
+	a, err := pq.EnQueue(&task{name: "a", priority: 1})
+	require.NoError(t, err)
+	_, err = pq.EnQueue(&task{name: "b", priority: 2})
+	require.NoError(t, err)
+
+	// 直接修改元素的字段，然后调用 Fix 重新调整位置
+	a.Value().priority = 3
+	require.NoError(t, pq.Fix(a))
+	top, err := pq.Peek()
+	require.NoError(t, err)
+	assert.Equal(t, "a", top.name)
+}
+
+func TestIndexedPriorityQueue_Remove(t *testing.T) {
+	pq := NewIndexedPriorityQueue[int](0, getIntComparator())
+	handles := make([]*Handle[int], 0, 6)
+	for _, val := range []int{5, 3, 8, 1, 9, 2} {
+		h, err := pq.EnQueue(val)
+		require.NoError(t, err)
+		handles = append(handles, h)
+	}
+
+	val, err := pq.Remove(handles[2])
+	require.NoError(t, err)
+	assert.Equal(t, 8, val)
+	assert.False(t, pq.Contains(handles[2]))
+
+	// 删除最后一个元素
+	val, err = pq.Remove(handles[5])
+	require.NoError(t, err)
+	assert.Equal(t, 2, val)
+
+	assert.Equal(t, []int{9, 5, 3, 1}, drainIndexed(t, pq))
+}
+
+func TestIndexedPriorityQueue_InvalidHandle(t *testing.T) {
+	pq := NewIndexedPriorityQueue[int](0, getIntComparator())
+	other := NewIndexedPriorityQueue[int](0, getIntComparator())
+	h, err := pq.EnQueue(1)
+	require.NoError(t, err)
+	otherHandle, err := other.EnQueue(2)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		handle *Handle[int]
+	}{
+		{
+			name:   "Test nil handle",
+			handle: nil,
+		},
+		{
+			name:   "Test handle of other queue",
+			handle: otherHandle,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, errs.NewErrInvalidHandle(), pq.Update(test.handle, 3))
+			assert.Equal(t, errs.NewErrInvalidHandle(), pq.Fix(test.handle))
+			_, err := pq.Remove(test.handle)
+			assert.Equal(t, errs.NewErrInvalidHandle(), err)
+		})
+	}
+
+	t.Run("Test dequeued handle", func(t *testing.T) {
+		_, err := pq.DeQueue()
+		require.NoError(t, err)
+		assert.Equal(t, errs.NewErrInvalidHandle(), pq.Update(h, 3))
+		_, err = pq.Remove(h)
+		assert.Equal(t, errs.NewErrInvalidHandle(), err)
+		_, err = pq.DeQueue()
+		assert.Equal(t, errs.NewErrEmptyQueue(), err)
+	})
+}
+
+func TestIndexedPriorityQueue_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(2024))
+	pq := NewIndexedPriorityQueue[int](0, getIntComparator())
+	live := make(map[*Handle[int]]struct{})
+	for i := 0; i < 5000; i++ {
+		switch op := r.Intn(4); {
+		case op <= 1 || len(live) == 0:
+			h, err := pq.EnQueue(r.Intn(1000))
+			require.NoError(t, err)
+			live[h] = struct{}{}
+		case op == 2:
+			for h := range live {
+				require.NoError(t, pq.Update(h, r.Intn(1000)))
+				break
+			}
+		default:
+			for h := range live {
+				val, err := pq.Remove(h)
+				require.NoError(t, err)
+				assert.Equal(t, h.Value(), val)
+				delete(live, h)
+				break
+			}
+		}
+	}
+
+	want := make([]int, 0, len(live))
+	for h := range live {
+		want = append(want, h.Value())
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(want)))
+	assert.Equal(t, len(want), pq.Len())
+	assert.Equal(t, want, drainIndexed(t, pq))
+}
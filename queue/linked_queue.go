@@ -0,0 +1,89 @@
+// Package queue
+/**
+* @Project : GenericGo
+* @File    : linked_queue.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/25 15:12
+**/
+
+package queue
+
+import (
+	genericgo "github.com/HJH0924/GenericGo"
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/list"
+)
+
+var (
+	_ Queue[any] = &LinkedQueue[any]{}
+)
+
+// LinkedQueue 是一个基于 list.LinkedList 实现的先进先出队列
+// 当 capacity <= 0 时，为无界队列
+// 当 capacity > 0 时，为有界队列，元素数量不能超过 capacity
+type LinkedQueue[T any] struct {
+	list     *list.LinkedList[T]
+	capacity int
+}
+
+// Len 返回队列中元素的数量。
+func (Self *LinkedQueue[T]) Len() int {
+	return Self.list.Len()
+}
+
+// Cap 返回队列的容量，无界队列返回0。
+func (Self *LinkedQueue[T]) Cap() int {
+	return Self.capacity
+}
+
+// IsEmpty 返回队列是否为空。
+func (Self *LinkedQueue[T]) IsEmpty() bool {
+	return Self.list.Len() == 0
+}
+
+// IsFull 返回队列是否已满。
+func (Self *LinkedQueue[T]) IsFull() bool {
+	return Self.capacity > 0 && Self.list.Len() == Self.capacity
+}
+
+// Peek 返回队首元素，但不移除它。如果队列为空，返回错误。
+func (Self *LinkedQueue[T]) Peek() (T, error) {
+	if Self.IsEmpty() {
+		return genericgo.Zero[T](), errs.NewErrEmptyQueue()
+	}
+	return Self.list.Get(0)
+}
+
+// EnQueue 将元素放入队尾。如果队列已满，返回错误。
+func (Self *LinkedQueue[T]) EnQueue(val T) error {
+	if Self.IsFull() {
+		return errs.NewErrOutOfCapacity()
+	}
+	Self.list.Append(val)
+	return nil
+}
+
+// DeQueue 从队列中移除并返回队首元素。如果队列为空，返回错误。
+func (Self *LinkedQueue[T]) DeQueue() (T, error) {
+	if Self.IsEmpty() {
+		return genericgo.Zero[T](), errs.NewErrEmptyQueue()
+	}
+	return Self.list.Delete(0)
+}
+
+// AsSlice 按照出队的顺序返回队列中元素的切片副本。
+func (Self *LinkedQueue[T]) AsSlice() []T {
+	return Self.list.AsSlice()
+}
+
+// NewLinkedQueue 创建一个新的 LinkedQueue，当 capacity <= 0 时，视为无界队列。
+func NewLinkedQueue[T any](capacity int) *LinkedQueue[T] {
+	if capacity < 0 {
+		capacity = 0
+	}
+	return &LinkedQueue[T]{
+		list:     list.NewLinkedList[T](),
+		capacity: capacity,
+	}
+}
@@ -0,0 +1,115 @@
+// Package queue
+/**
+* @Project : GenericGo
+* @File    : linked_queue_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/25 15:40
+**/
+
+package queue
+
+import (
+	"testing"
+
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkedQueue_EnQueue(t *testing.T) {
+	tests := []struct {
+		name      string
+		capacity  int
+		vals      []int
+		wantSlice []int
+		wantErr   error
+	}{
+		{
+			name:      "Test bounded queue not full",
+			capacity:  5,
+			vals:      []int{1, 2, 3},
+			wantSlice: []int{1, 2, 3},
+		},
+		{
+			name:      "Test bounded queue full",
+			capacity:  3,
+			vals:      []int{1, 2, 3, 4},
+			wantSlice: []int{1, 2, 3},
+			wantErr:   errs.NewErrOutOfCapacity(),
+		},
+		{
+			name:      "Test unbounded queue",
+			capacity:  0,
+			vals:      makeRange(0, 100),
+			wantSlice: makeRange(0, 100),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q := NewLinkedQueue[int](test.capacity)
+			var err error
+			for _, val := range test.vals {
+				if err = q.EnQueue(val); err != nil {
+					break
+				}
+			}
+			assert.Equal(t, test.wantErr, err)
+			assert.Equal(t, test.wantSlice, q.AsSlice())
+			assert.Equal(t, len(test.wantSlice), q.Len())
+			assert.Equal(t, test.capacity > 0 && len(test.wantSlice) == test.capacity, q.IsFull())
+		})
+	}
+}
+
+func TestLinkedQueue_DeQueue(t *testing.T) {
+	tests := []struct {
+		name     string
+		vals     []int
+		wantVals []int
+	}{
+		{
+			name:     "Test empty queue",
+			vals:     []int{},
+			wantVals: []int{},
+		},
+		{
+			name:     "Test non-empty queue",
+			vals:     []int{3, 1, 2},
+			wantVals: []int{3, 1, 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q := NewLinkedQueue[int](0)
+			for _, val := range test.vals {
+				require.NoError(t, q.EnQueue(val))
+			}
+			res := make([]int, 0, len(test.vals))
+			for !q.IsEmpty() {
+				val, err := q.DeQueue()
+				require.NoError(t, err)
+				res = append(res, val)
+			}
+			assert.Equal(t, test.wantVals, res)
+
+			_, err := q.DeQueue()
+			assert.Equal(t, errs.NewErrEmptyQueue(), err)
+		})
+	}
+}
+
+func TestLinkedQueue_Peek(t *testing.T) {
+	q := NewLinkedQueue[int](0)
+	_, err := q.Peek()
+	assert.Equal(t, errs.NewErrEmptyQueue(), err)
+
+	require.NoError(t, q.EnQueue(1))
+	require.NoError(t, q.EnQueue(2))
+	val, err := q.Peek()
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+	assert.Equal(t, 2, q.Len())
+}
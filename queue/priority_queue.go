@@ -12,16 +12,26 @@ package queue
 import (
 	genericgo "github.com/HJH0924/GenericGo"
 	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/internal/heapx"
+	"github.com/HJH0924/GenericGo/iterator"
+	"github.com/HJH0924/GenericGo/option"
 	"github.com/HJH0924/GenericGo/slice"
 )
 
-// PriorityQueue 是一个基于大根堆的优先级队列
+var (
+	_ Queue[any]             = &PriorityQueue[any]{}
+	_ iterator.Iterable[any] = &PriorityQueue[any]{}
+)
+
+// PriorityQueue 是一个基于大根堆的优先级队列，compare 返回值大于0表示左边的元素优先级更高
 // 当 capacity <= 0 时，为无界队列，切片容量会动态扩缩容
 // 当 capacity > 0 时，为有界队列，初始化后就固定容量，不会扩缩容
-// 为了方便计算节点的索引，底层切片的第一个元素（索引0）留空，实际元素从索引1开始。
+// 默认使用二叉堆，可以通过 WithArity 使用 d 叉堆，元素较多时更少的层数对缓存更友好。
+// 底层切片的第一个元素（索引0）留空，实际元素从索引1开始。
 type PriorityQueue[T any] struct {
 	compare  genericgo.Comparator[T] // 用于比较两个元素的优先级
 	capacity int                     // 优先级队列的容量
+	arity    int                     // 堆中每个节点最多拥有的子节点数量
 	vals     []T                     // 存储优先级队列中元素的切片，索引1为根节点（堆顶）
 }
 
@@ -69,22 +79,10 @@ func (pq *PriorityQueue[T]) EnQueue(val T) error {
 	return nil
 }
 
-// upHeapify 重新调整堆以维持大根堆的性质，从堆的末尾元素开始向上调整。
+// upHeapify 重新调整堆以维持堆的性质，从堆的末尾元素开始向上调整。
 func (pq *PriorityQueue[T]) upHeapify() {
-	nodeIndex := pq.Len()
-
 	// 上浮过程：从新元素开始，逐层向上比较并交换，直到满足堆的性质或到达堆顶
-	for nodeIndex > 1 {
-		parentIndex := nodeIndex / 2
-		if pq.compare(pq.vals[nodeIndex], pq.vals[parentIndex]) > 0 {
-			// 当前节点的优先级高于父节点
-			pq.vals[nodeIndex], pq.vals[parentIndex] = pq.vals[parentIndex], pq.vals[nodeIndex]
-			nodeIndex = parentIndex
-		} else {
-			// 当前节点值的优先级不高于父节点，上浮结束
-			break
-		}
-	}
+	heapx.Up(pq.vals[1:], pq.Len()-1, pq.arity, pq.compare)
 }
 
 // DeQueue 从优先级队列中移除并返回顶部的元素，即优先级最高的元素。
@@ -100,38 +98,29 @@ func (pq *PriorityQueue[T]) DeQueue() (T, error) {
 
 	// 无界队列可能需要缩容
 	if pq.IsBoundLess() {
-		slice.ShrinkSlice(pq.vals)
+		pq.vals = slice.ShrinkSlice(pq.vals)
 	}
 
 	pq.downHeapify()
 	return val, nil
 }
 
-// downHeapify 重新调整堆，从根节点开始向下调整，以维持大根堆的性质。
+// downHeapify 重新调整堆，从根节点开始向下调整，以维持堆的性质。
 func (pq *PriorityQueue[T]) downHeapify() {
-	// maxPos 用于记录当前节点及其子节点中最大元素的位置。
-	// i 是当前节点的索引，从1开始，即根节点。
-	// n 是优先级队列中元素的数量。
-	maxPos, i, n := 1, 1, pq.Len()
-
-	for {
-		if left := 2 * i; left <= n && pq.compare(pq.vals[left], pq.vals[maxPos]) > 0 {
-			// 左孩子存在，且左孩子的优先级高于当前最大节点
-			maxPos = left
-		}
-		if right := 2*i + 1; right <= n && pq.compare(pq.vals[right], pq.vals[maxPos]) > 0 {
-			// 右孩子存在，且右孩子的优先级高于当前最大节点
-			maxPos = right
-		}
-		// 如果最小位置没有变化，或者没有子节点，退出循环
-		if maxPos == i {
-			break
-		}
-		// 交换当前节点与最大子节点的值
-		pq.vals[maxPos], pq.vals[i] = pq.vals[i], pq.vals[maxPos]
-		// 更新当前索引为最大位置索引，继续向下调整
-		i = maxPos
+	// 下沉过程：将根节点与优先级最高的子节点交换，直到满足堆的性质或到达叶子节点
+	heapx.Down(pq.vals[1:], 0, pq.arity, pq.compare)
+}
+
+// Merge 将 other 中的所有元素合并到当前队列中，other 不会被修改。
+// 两个队列应当使用相同的比较规则，合并后按照当前队列的比较函数重新建堆，时间复杂度为 O(n+m)。
+// 如果合并后元素数量超过当前队列的容量，返回错误，当前队列不会被修改。
+func (pq *PriorityQueue[T]) Merge(other *PriorityQueue[T]) error {
+	if pq.Cap() > 0 && pq.Len()+other.Len() > pq.Cap() {
+		return errs.NewErrOutOfCapacity()
 	}
+	pq.vals = append(pq.vals, other.vals[1:]...)
+	heapx.Heapify(pq.vals[1:], pq.arity, pq.compare)
+	return nil
 }
 
 // AsSlice 返回优先级队列中元素的切片副本。
@@ -144,10 +133,16 @@ func (pq *PriorityQueue[T]) AsSlice() []T {
 	return res
 }
 
-// NewPriorityQueue 创建一个新的优先级队列。
+// Iterator 返回遍历队列中所有元素的迭代器，元素按照堆中的顺序排列，与 AsSlice 的顺序一致，
+// 第一个元素为优先级最高的元素，之后的元素不保证有序。迭代器不会复制底层切片，也不会修改队列。
+func (pq *PriorityQueue[T]) Iterator() iterator.Iterator[T] {
+	return iterator.FromSlice(pq.vals[1:])
+}
+
+// NewPriorityQueue 创建一个新的优先级队列，compare 返回值越大的元素优先级越高，即大根堆。
 // 接受容量参数和比较函数，用于确定元素的优先级顺序。
 // 当 capacity <= 0 时，视为无界队列，初始大小使用默认值64。
-func NewPriorityQueue[T any](capacity int, compare genericgo.Comparator[T]) *PriorityQueue[T] {
+func NewPriorityQueue[T any](capacity int, compare genericgo.Comparator[T], opts ...option.Option[PriorityQueue[T]]) *PriorityQueue[T] {
 	const (
 		DefaultInitialCap = 64
 	)
@@ -156,23 +151,48 @@ func NewPriorityQueue[T any](capacity int, compare genericgo.Comparator[T]) *Pri
 		capacity = 0
 		initialCap = DefaultInitialCap
 	}
-	return &PriorityQueue[T]{
+	pq := &PriorityQueue[T]{
 		compare:  compare,
 		capacity: capacity,
+		arity:    2,
 		vals:     make([]T, 1, initialCap), // +1 为了适应传统的堆实现中的虚拟头节点。
 	}
+	option.Apply(pq, opts...)
+	return pq
+}
+
+// NewMaxPriorityQueue 创建一个大根堆优先级队列，compare 返回值越大的元素越先出队，等价于 NewPriorityQueue。
+func NewMaxPriorityQueue[T any](capacity int, compare genericgo.Comparator[T], opts ...option.Option[PriorityQueue[T]]) *PriorityQueue[T] {
+	return NewPriorityQueue(capacity, compare, opts...)
+}
+
+// NewMinPriorityQueue 创建一个小根堆优先级队列，compare 返回值越小的元素越先出队。
+// 调用方无需为了得到小根堆而自己翻转比较函数。
+func NewMinPriorityQueue[T any](capacity int, compare genericgo.Comparator[T], opts ...option.Option[PriorityQueue[T]]) *PriorityQueue[T] {
+	return NewPriorityQueue(capacity, func(l, r T) int {
+		return compare(r, l)
+	}, opts...)
 }
 
 // NewPriorityQueueOf 创建一个具有初始值的优先级队列。
 // 它接受一个容量、一个包含初始元素的切片，以及一个比较函数。
-// 如果添加元素时出现错误，将返回nil。
-func NewPriorityQueueOf[T any](capacity int, vals []T, compare genericgo.Comparator[T]) *PriorityQueue[T] {
-	pq := NewPriorityQueue[T](capacity, compare)
-	for _, val := range vals {
-		err := pq.EnQueue(val)
-		if err != nil {
-			return nil
+// 初始元素通过自底向上建堆的方式一次性加入，时间复杂度为 O(n)。
+// 如果初始元素的数量超过了容量，返回 errs.NewErrOutOfCapacity。
+func NewPriorityQueueOf[T any](capacity int, vals []T, compare genericgo.Comparator[T], opts ...option.Option[PriorityQueue[T]]) (*PriorityQueue[T], error) {
+	if capacity > 0 && len(vals) > capacity {
+		return nil, errs.NewErrOutOfCapacity()
+	}
+	pq := NewPriorityQueue[T](capacity, compare, opts...)
+	pq.vals = append(pq.vals, vals...)
+	heapx.Heapify(pq.vals[1:], pq.arity, pq.compare)
+	return pq, nil
+}
+
+// WithArity 设置堆中每个节点最多拥有的子节点数量，默认为2，即二叉堆。小于2的值会被忽略。
+func WithArity[T any](arity int) option.Option[PriorityQueue[T]] {
+	return func(pq *PriorityQueue[T]) {
+		if arity >= 2 {
+			pq.arity = arity
 		}
 	}
-	return pq
 }
@@ -10,10 +10,14 @@
 package queue
 
 import (
+	"fmt"
+	"math/rand"
+	"sort"
 	"testing"
 
 	genericgo "github.com/HJH0924/GenericGo"
 	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/iterator"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -86,7 +90,8 @@ func TestPriorityQueue_Len(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			pq := NewPriorityQueueOf(0, test.vals, getIntComparator())
+			pq, err := NewPriorityQueueOf(0, test.vals, getIntComparator())
+			require.NoError(t, err)
 			assert.Equal(t, test.wantLen, pq.Len())
 		})
 	}
@@ -115,7 +120,8 @@ func TestPriorityQueue_Cap(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			pq := NewPriorityQueueOf(test.capacity, test.vals, getIntComparator())
+			pq, err := NewPriorityQueueOf(test.capacity, test.vals, getIntComparator())
+			require.NoError(t, err)
 			assert.Equal(t, test.wantCap, pq.Cap())
 		})
 	}
@@ -150,7 +156,8 @@ func TestPriorityQueue_IsBoundLess(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			pq := NewPriorityQueueOf(test.capacity, test.vals, getIntComparator())
+			pq, err := NewPriorityQueueOf(test.capacity, test.vals, getIntComparator())
+			require.NoError(t, err)
 			assert.Equal(t, test.wantIsBoundLess, pq.IsBoundLess())
 		})
 	}
@@ -191,7 +198,8 @@ func TestPriorityQueue_IsFull(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			pq := NewPriorityQueueOf(test.capacity, test.vals, getIntComparator())
+			pq, err := NewPriorityQueueOf(test.capacity, test.vals, getIntComparator())
+			require.NoError(t, err)
 			assert.Equal(t, test.wantIsFull, pq.IsFull())
 		})
 	}
@@ -232,7 +240,8 @@ func TestPriorityQueue_IsEmpty(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			pq := NewPriorityQueueOf(test.capacity, test.vals, getIntComparator())
+			pq, err := NewPriorityQueueOf(test.capacity, test.vals, getIntComparator())
+			require.NoError(t, err)
 			assert.Equal(t, test.wantIsEmpty, pq.IsEmpty())
 		})
 	}
@@ -261,7 +270,8 @@ func TestPriorityQueue_Peek(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			pq := NewPriorityQueueOf(test.capacity, test.vals, getIntComparator())
+			pq, err := NewPriorityQueueOf(test.capacity, test.vals, getIntComparator())
+			require.NoError(t, err)
 			for !pq.IsEmpty() {
 				peek, err := pq.Peek()
 				assert.NoError(t, err)
@@ -269,7 +279,7 @@ func TestPriorityQueue_Peek(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, pop, peek)
 			}
-			_, err := pq.Peek()
+			_, err = pq.Peek()
 			assert.Equal(t, test.wantErr, err)
 		})
 	}
@@ -318,9 +328,9 @@ func TestPriorityQueue_EnQueue(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			pq := NewPriorityQueueOf(test.capacity, test.vals, getIntComparator())
-			require.NotNil(t, pq)
-			err := pq.EnQueue(test.enVal)
+			pq, err := NewPriorityQueueOf(test.capacity, test.vals, getIntComparator())
+			require.NoError(t, err)
+			err = pq.EnQueue(test.enVal)
 			assert.Equal(t, test.wantErr, err)
 			assert.Equal(t, test.capacity, pq.Cap())
 		})
@@ -361,9 +371,9 @@ func TestPriorityQueue_EnQueue2(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		pq := NewPriorityQueueOf(0, test.vals, getIntComparator())
-		require.NotNil(t, pq)
-		err := pq.EnQueue(test.enVal)
+		pq, err := NewPriorityQueueOf(0, test.vals, getIntComparator())
+		require.NoError(t, err)
+		err = pq.EnQueue(test.enVal)
 		assert.NoError(t, err)
 		assert.Equal(t, test.expected, pq.AsSlice())
 	}
@@ -394,7 +404,8 @@ func TestPriorityQueue_DeQueue(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			pq := NewPriorityQueueOf(test.capacity, test.vals, getIntComparator())
+			pq, err := NewPriorityQueueOf(test.capacity, test.vals, getIntComparator())
+			require.NoError(t, err)
 			getDeVal, getErr := pq.DeQueue()
 			if getErr != nil {
 				assert.Equal(t, test.wantErr, getErr)
@@ -414,7 +425,7 @@ func TestPriorityQueue_AsSlice(t *testing.T) {
 		{
 			name:     "",
 			vals:     []int{41, 62, 67, 87, 41, 78, 45, 28, 25, 58},
-			wantVals: []int{87, 67, 78, 41, 58, 62, 45, 28, 25, 41},
+			wantVals: []int{87, 62, 78, 41, 58, 67, 45, 28, 25, 41}, // 自底向上建堆得到的布局
 		},
 		{
 			name:     "",
@@ -425,12 +436,185 @@ func TestPriorityQueue_AsSlice(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			pq := NewPriorityQueueOf(-1, test.vals, getIntComparator())
+			pq, err := NewPriorityQueueOf(-1, test.vals, getIntComparator())
+			require.NoError(t, err)
 			assert.Equal(t, test.wantVals, pq.AsSlice())
 		})
 	}
 }
 
+func TestNewMinPriorityQueue(t *testing.T) {
+	tests := []struct {
+		name     string
+		newQueue func() *PriorityQueue[int]
+		vals     []int
+		wantVals []int
+	}{
+		{
+			name: "Test min priority queue",
+			newQueue: func() *PriorityQueue[int] {
+				return NewMinPriorityQueue[int](0, getIntComparator())
+			},
+			vals:     []int{5, 1, 4, 2, 3},
+			wantVals: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name: "Test max priority queue",
+			newQueue: func() *PriorityQueue[int] {
+				return NewMaxPriorityQueue[int](0, getIntComparator())
+			},
+			vals:     []int{5, 1, 4, 2, 3},
+			wantVals: []int{5, 4, 3, 2, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pq := test.newQueue()
+			for _, val := range test.vals {
+				require.NoError(t, pq.EnQueue(val))
+			}
+			assert.Equal(t, test.wantVals, drainPriorityQueue(t, pq))
+		})
+	}
+}
+
+func TestPriorityQueue_WithArity(t *testing.T) {
+	r := rand.New(rand.NewSource(2024))
+	vals := make([]int, 1000)
+	for i := range vals {
+		vals[i] = r.Intn(500)
+	}
+	want := make([]int, len(vals))
+	copy(want, vals)
+	sort.Sort(sort.Reverse(sort.IntSlice(want)))
+
+	for _, arity := range []int{0, 1, 2, 3, 4, 8} {
+		t.Run(fmt.Sprintf("Test arity %d", arity), func(t *testing.T) {
+			// 逐个入队
+			pq := NewPriorityQueue[int](0, getIntComparator(), WithArity[int](arity))
+			for _, val := range vals {
+				require.NoError(t, pq.EnQueue(val))
+			}
+			assert.Equal(t, want, drainPriorityQueue(t, pq))
+
+			// 一次性建堆
+			pq, err := NewPriorityQueueOf[int](0, vals, getIntComparator(), WithArity[int](arity))
+			require.NoError(t, err)
+			assert.Equal(t, want, drainPriorityQueue(t, pq))
+		})
+	}
+}
+
+func TestNewPriorityQueueOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		vals     []int
+		wantErr  error
+		wantVals []int
+	}{
+		{
+			name:     "Test unbounded",
+			capacity: 0,
+			vals:     []int{3, 1, 2},
+			wantVals: []int{3, 2, 1},
+		},
+		{
+			name:     "Test exactly full",
+			capacity: 3,
+			vals:     []int{3, 1, 2},
+			wantVals: []int{3, 2, 1},
+		},
+		{
+			name:     "Test overflow",
+			capacity: 2,
+			vals:     []int{3, 1, 2},
+			wantErr:  errs.NewErrOutOfCapacity(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pq, err := NewPriorityQueueOf(test.capacity, test.vals, getIntComparator())
+			assert.Equal(t, test.wantErr, err)
+			if err != nil {
+				assert.Nil(t, pq)
+				return
+			}
+			assert.Equal(t, test.wantVals, drainPriorityQueue(t, pq))
+			// 建堆不会修改传入的切片
+			assert.Equal(t, []int{3, 1, 2}, test.vals)
+		})
+	}
+}
+
+func TestPriorityQueue_Merge(t *testing.T) {
+	tests := []struct {
+		name      string
+		capacity  int
+		vals      []int
+		otherVals []int
+		wantVals  []int
+		wantErr   error
+	}{
+		{
+			name:      "Test merge into unbounded queue",
+			capacity:  0,
+			vals:      []int{1, 5, 3},
+			otherVals: []int{4, 2, 6},
+			wantVals:  []int{6, 5, 4, 3, 2, 1},
+		},
+		{
+			name:      "Test merge empty queue",
+			capacity:  0,
+			vals:      []int{1, 2},
+			otherVals: []int{},
+			wantVals:  []int{2, 1},
+		},
+		{
+			name:      "Test merge into bounded queue",
+			capacity:  4,
+			vals:      []int{1, 2},
+			otherVals: []int{3, 4},
+			wantVals:  []int{4, 3, 2, 1},
+		},
+		{
+			name:      "Test merge overflow",
+			capacity:  3,
+			vals:      []int{1, 2},
+			otherVals: []int{3, 4},
+			wantVals:  []int{2, 1},
+			wantErr:   errs.NewErrOutOfCapacity(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pq, err := NewPriorityQueueOf(test.capacity, test.vals, getIntComparator())
+			require.NoError(t, err)
+			other, err := NewPriorityQueueOf(0, test.otherVals, getIntComparator())
+			require.NoError(t, err)
+			err = pq.Merge(other)
+			assert.Equal(t, test.wantErr, err)
+			// other 不会被修改
+			assert.Equal(t, len(test.otherVals), other.Len())
+			assert.Equal(t, test.wantVals, drainPriorityQueue(t, pq))
+		})
+	}
+}
+
+// drainPriorityQueue 依次出队所有元素
+func drainPriorityQueue[T any](t *testing.T, pq *PriorityQueue[T]) []T {
+	res := make([]T, 0, pq.Len())
+	for !pq.IsEmpty() {
+		val, err := pq.DeQueue()
+		require.NoError(t, err)
+		res = append(res, val)
+	}
+	return res
+}
+
 func getIntComparator() genericgo.Comparator[int] {
 	return func(left int, right int) int {
 		if left > right {
@@ -442,3 +626,29 @@ func getIntComparator() genericgo.Comparator[int] {
 		}
 	}
 }
+
+func TestPriorityQueue_Iterator(t *testing.T) {
+	tests := []struct {
+		name string
+		vals []int
+	}{
+		{
+			name: "Empty queue",
+			vals: []int{},
+		},
+		{
+			name: "Multiple values",
+			vals: []int{41, 62, 67, 87, 41, 78, 45, 28, 25, 58},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pq, err := NewPriorityQueueOf(0, test.vals, getIntComparator())
+			require.NoError(t, err)
+			// 迭代器的顺序与 AsSlice 一致，且不会修改队列
+			assert.Equal(t, pq.AsSlice(), iterator.Collect(pq.Iterator()))
+			assert.Equal(t, len(test.vals), pq.Len())
+		})
+	}
+}
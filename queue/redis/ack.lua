@@ -0,0 +1,27 @@
+--[[
+    确认消息已经处理完成，删除消息
+    输入：
+    KEYS[1] - 未确认集合（有序集合，score 为消息的可见性超时时间）
+    KEYS[2] - 消息内容（哈希表，field 为消息 ID）
+    KEYS[3] - 投递次数（哈希表，field 为消息 ID）
+    ARGV[1] - 消息 ID
+    ARGV[2] - 被确认的是第几次投递
+    输出：
+    1 - 确认成功
+    0 - 消息不在未确认集合中或者投递次数不一致，可能已经被确认，或者因为可见性超时被重新投递
+--]]
+
+local unacked = KEYS[1]
+local data = KEYS[2]
+local attempts = KEYS[3]
+
+local id = ARGV[1]
+local attempt = ARGV[2]
+
+if redis.call('ZSCORE', unacked, id) == false or redis.call('HGET', attempts, id) ~= attempt then
+    return 0
+end
+redis.call('ZREM', unacked, id)
+redis.call('HDEL', data, id)
+redis.call('HDEL', attempts, id)
+return 1
@@ -0,0 +1,182 @@
+// Package redis
+/**
+* @Project : GenericGo
+* @File    : delay_queue.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/28 10:12
+**/
+
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/HJH0924/GenericGo/option"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	NewErrMessageNotFound = errors.New("消息不存在，可能已经被确认或者已被重新投递")
+)
+
+var (
+	//go:embed enqueue.lua
+	enqueueLua string
+	//go:embed dequeue.lua
+	dequeueLua string
+	//go:embed ack.lua
+	ackLua string
+)
+
+const (
+	defaultVisibilityTimeout = 30 * time.Second
+	defaultPollInterval      = 100 * time.Millisecond
+	// redeliverBatch 每次出队时最多重新投递的超时消息数量，避免单次脚本执行时间过长
+	redeliverBatch = 100
+)
+
+// Message 是从 DelayQueue 中取出的消息
+type Message struct {
+	ID      string
+	Payload string
+	// Attempt 表示这是消息第几次被投递，从1开始。
+	// 同一条消息每次被重新投递都会得到新的 Attempt，Ack 时用它区分不同的投递。
+	Attempt int64
+}
+
+// DelayQueue 基于 Redis 的分布式延时队列，多个进程可以共享同一个队列。
+// 数据保存在四个使用相同 hash tag 的键中，因此在 Redis Cluster 下也能使用 Lua 脚本：
+//   - {name}:delayed  有序集合，score 为消息可以被消费的时间；
+//   - {name}:unacked  有序集合，score 为消息的可见性超时时间；
+//   - {name}:data     哈希表，保存消息内容；
+//   - {name}:attempts 哈希表，保存消息的投递次数。
+//
+// 所有的时间都在 Lua 脚本中通过 Redis 的 TIME 命令获取，多个进程之间的时钟偏差不会影响消息的到期和重新投递。
+//
+// 消息被取出后进入未确认状态，如果在可见性超时时间内没有调用 Ack，会被重新投递，
+// 所以消息至少会被消费一次，消费者需要自行保证幂等。
+type DelayQueue struct {
+	client            redis.Cmdable
+	delayedKey        string
+	unackedKey        string
+	dataKey           string
+	attemptsKey       string
+	visibilityTimeout time.Duration
+	pollInterval      time.Duration
+
+	newID func() string
+}
+
+// EnQueue 将消息放入队列，消息在 delay 之后才可以被消费，返回消息的 ID。
+func (Self *DelayQueue) EnQueue(ctx context.Context, payload string, delay time.Duration) (string, error) {
+	id := Self.newID()
+	err := Self.client.Eval(ctx, enqueueLua, []string{Self.delayedKey, Self.dataKey}, id, payload, delay.Milliseconds()).Err()
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// DeQueue 取出最早到期的消息，如果没有到期的消息，则轮询等待直到有消息到期或者 ctx 结束。
+// 取出的消息需要在可见性超时时间内调用 Ack 确认，否则会被重新投递。
+func (Self *DelayQueue) DeQueue(ctx context.Context) (Message, error) {
+	for {
+		msg, err := Self.tryDeQueue(ctx)
+		if err == nil {
+			return msg, nil
+		}
+		if !errors.Is(err, redis.Nil) {
+			return Message{}, err
+		}
+
+		timer := time.NewTimer(Self.pollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return Message{}, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// tryDeQueue 尝试取出一条到期的消息，没有到期的消息时返回 redis.Nil
+func (Self *DelayQueue) tryDeQueue(ctx context.Context) (Message, error) {
+	res, err := Self.client.Eval(ctx, dequeueLua, []string{Self.delayedKey, Self.unackedKey, Self.dataKey, Self.attemptsKey},
+		Self.visibilityTimeout.Milliseconds(), redeliverBatch).StringSlice()
+	if err != nil {
+		return Message{}, err
+	}
+	if len(res) != 3 {
+		return Message{}, errors.New("出队脚本返回了非预期的结果")
+	}
+	attempt, err := strconv.ParseInt(res[2], 10, 64)
+	if err != nil {
+		return Message{}, errors.New("出队脚本返回了非预期的结果")
+	}
+	return Message{
+		ID:      res[0],
+		Payload: res[1],
+		Attempt: attempt,
+	}, nil
+}
+
+// Ack 确认 DeQueue 返回的消息已经处理完成，确认之后消息会被删除，不会再被投递。
+// 如果消息已经被确认，或者因为可见性超时已经被重新投递（即使新的投递尚未被确认），返回 NewErrMessageNotFound。
+func (Self *DelayQueue) Ack(ctx context.Context, msg Message) error {
+	res, err := Self.client.Eval(ctx, ackLua, []string{Self.unackedKey, Self.dataKey, Self.attemptsKey},
+		msg.ID, msg.Attempt).Int64()
+	if err != nil {
+		return err
+	}
+	if res == 0 {
+		return NewErrMessageNotFound
+	}
+	return nil
+}
+
+// NewDelayQueue 创建一个名为 name 的 DelayQueue，相同 name 的 DelayQueue 共享同一个队列。
+func NewDelayQueue(client redis.Cmdable, name string, opts ...option.Option[DelayQueue]) *DelayQueue {
+	prefix := "{" + name + "}"
+	res := &DelayQueue{
+		client:            client,
+		delayedKey:        prefix + ":delayed",
+		unackedKey:        prefix + ":unacked",
+		dataKey:           prefix + ":data",
+		attemptsKey:       prefix + ":attempts",
+		visibilityTimeout: defaultVisibilityTimeout,
+		pollInterval:      defaultPollInterval,
+		newID:             newMessageID,
+	}
+	option.Apply(res, opts...)
+	return res
+}
+
+// WithVisibilityTimeout 设置消息的可见性超时时间，默认为30秒。
+// 消息被取出后，如果在该时间内没有被确认，会被重新投递。
+func WithVisibilityTimeout(timeout time.Duration) option.Option[DelayQueue] {
+	return func(q *DelayQueue) {
+		q.visibilityTimeout = timeout
+	}
+}
+
+// WithPollInterval 设置 DeQueue 在没有到期消息时的轮询间隔，默认为100毫秒。
+func WithPollInterval(interval time.Duration) option.Option[DelayQueue] {
+	return func(q *DelayQueue) {
+		q.pollInterval = interval
+	}
+}
+
+// newMessageID 生成一个随机的消息 ID
+func newMessageID() string {
+	var buf [16]byte
+	// crypto/rand.Read 在支持的平台上不会返回错误
+	_, _ = rand.Read(buf[:])
+	return hex.EncodeToString(buf[:])
+}
@@ -0,0 +1,272 @@
+// Package redis
+/**
+* @Project : GenericGo
+* @File    : delay_queue_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/28 14:36
+**/
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HJH0924/GenericGo/ratelimiter/redismocks"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	testKeys    = []string{"{jobs}:delayed", "{jobs}:unacked", "{jobs}:data", "{jobs}:attempts"}
+	testVisible = time.Minute
+)
+
+// newTestDelayQueue 创建一个消息 ID 固定的 DelayQueue
+func newTestDelayQueue(client redis.Cmdable) *DelayQueue {
+	q := NewDelayQueue(client, "jobs", WithVisibilityTimeout(testVisible), WithPollInterval(time.Millisecond))
+	q.newID = func() string {
+		return "id-1"
+	}
+	return q
+}
+
+func TestDelayQueue_EnQueue(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) redis.Cmdable
+		wantID  string
+		wantErr error
+	}{
+		{
+			name: "入队成功",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				mockRedis := redismocks.NewMockCmdable(ctrl)
+				mockRedis.EXPECT().Eval(gomock.Any(), enqueueLua, []string{testKeys[0], testKeys[2]},
+					"id-1", "payload", time.Second.Milliseconds()).
+					Return(redis.NewCmdResult(int64(1), nil))
+				return mockRedis
+			},
+			wantID: "id-1",
+		},
+		{
+			name: "系统错误",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				mockRedis := redismocks.NewMockCmdable(ctrl)
+				mockRedis.EXPECT().Eval(gomock.Any(), enqueueLua, []string{testKeys[0], testKeys[2]},
+					"id-1", "payload", time.Second.Milliseconds()).
+					Return(redis.NewCmdResult(nil, errors.New("系统错误")))
+				return mockRedis
+			},
+			wantErr: errors.New("系统错误"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			q := newTestDelayQueue(tt.mock(ctrl))
+			id, err := q.EnQueue(context.Background(), "payload", time.Second)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantID, id)
+		})
+	}
+}
+
+func TestDelayQueue_DeQueue(t *testing.T) {
+	dequeueArgs := []any{testVisible.Milliseconds(), redeliverBatch}
+
+	tests := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) redis.Cmdable
+		timeout time.Duration
+		wantMsg Message
+		wantErr error
+	}{
+		{
+			name: "有到期的消息",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				mockRedis := redismocks.NewMockCmdable(ctrl)
+				mockRedis.EXPECT().Eval(gomock.Any(), dequeueLua, testKeys, dequeueArgs...).
+					Return(redis.NewCmdResult([]any{"id-1", "payload", "1"}, nil))
+				return mockRedis
+			},
+			timeout: time.Second,
+			wantMsg: Message{ID: "id-1", Payload: "payload", Attempt: 1},
+		},
+		{
+			name: "轮询直到有消息到期",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				mockRedis := redismocks.NewMockCmdable(ctrl)
+				gomock.InOrder(
+					mockRedis.EXPECT().Eval(gomock.Any(), dequeueLua, testKeys, dequeueArgs...).
+						Return(redis.NewCmdResult(nil, redis.Nil)).Times(2),
+					mockRedis.EXPECT().Eval(gomock.Any(), dequeueLua, testKeys, dequeueArgs...).
+						Return(redis.NewCmdResult([]any{"id-2", "payload", "2"}, nil)),
+				)
+				return mockRedis
+			},
+			timeout: time.Second,
+			wantMsg: Message{ID: "id-2", Payload: "payload", Attempt: 2},
+		},
+		{
+			name: "等待超时",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				mockRedis := redismocks.NewMockCmdable(ctrl)
+				mockRedis.EXPECT().Eval(gomock.Any(), dequeueLua, testKeys, dequeueArgs...).
+					Return(redis.NewCmdResult(nil, redis.Nil)).MinTimes(1)
+				return mockRedis
+			},
+			timeout: 20 * time.Millisecond,
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name: "系统错误",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				mockRedis := redismocks.NewMockCmdable(ctrl)
+				mockRedis.EXPECT().Eval(gomock.Any(), dequeueLua, testKeys, dequeueArgs...).
+					Return(redis.NewCmdResult(nil, errors.New("系统错误")))
+				return mockRedis
+			},
+			timeout: time.Second,
+			wantErr: errors.New("系统错误"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			q := newTestDelayQueue(tt.mock(ctrl))
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			msg, err := q.DeQueue(ctx)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantMsg, msg)
+		})
+	}
+}
+
+func TestDelayQueue_Ack(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) redis.Cmdable
+		wantErr error
+	}{
+		{
+			name: "确认成功",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				mockRedis := redismocks.NewMockCmdable(ctrl)
+				mockRedis.EXPECT().Eval(gomock.Any(), ackLua, []string{testKeys[1], testKeys[2], testKeys[3]}, "id-1", int64(2)).
+					Return(redis.NewCmdResult(int64(1), nil))
+				return mockRedis
+			},
+		},
+		{
+			name: "消息已被重新投递",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				mockRedis := redismocks.NewMockCmdable(ctrl)
+				mockRedis.EXPECT().Eval(gomock.Any(), ackLua, []string{testKeys[1], testKeys[2], testKeys[3]}, "id-1", int64(2)).
+					Return(redis.NewCmdResult(int64(0), nil))
+				return mockRedis
+			},
+			wantErr: NewErrMessageNotFound,
+		},
+		{
+			name: "系统错误",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				mockRedis := redismocks.NewMockCmdable(ctrl)
+				mockRedis.EXPECT().Eval(gomock.Any(), ackLua, []string{testKeys[1], testKeys[2], testKeys[3]}, "id-1", int64(2)).
+					Return(redis.NewCmdResult(nil, errors.New("系统错误")))
+				return mockRedis
+			},
+			wantErr: errors.New("系统错误"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			q := newTestDelayQueue(tt.mock(ctrl))
+			err := q.Ack(context.Background(), Message{ID: "id-1", Payload: "payload", Attempt: 2})
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+// TestDelayQueue_Miniredis 在 miniredis 上执行真实的 Lua 脚本，通过 miniredis 控制 TIME 命令返回的时间
+func TestDelayQueue_Miniredis(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Skipf("无法启动 miniredis: %v", err)
+	}
+	defer mr.Close()
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	ctx := context.Background()
+	start := time.UnixMilli(1732760000000)
+	mr.SetTime(start)
+	q := NewDelayQueue(client, "jobs", WithVisibilityTimeout(testVisible), WithPollInterval(time.Millisecond))
+
+	idA, err := q.EnQueue(ctx, "a", 10*time.Second)
+	require.NoError(t, err)
+	idB, err := q.EnQueue(ctx, "b", 5*time.Second)
+	require.NoError(t, err)
+
+	// 还没有到期的消息
+	_, err = q.tryDeQueue(ctx)
+	assert.Equal(t, redis.Nil, err)
+
+	// 按照到期时间的先后出队
+	mr.SetTime(start.Add(5 * time.Second))
+	msgB, err := q.tryDeQueue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Message{ID: idB, Payload: "b", Attempt: 1}, msgB)
+	_, err = q.tryDeQueue(ctx)
+	assert.Equal(t, redis.Nil, err)
+
+	mr.SetTime(start.Add(10 * time.Second))
+	msgA, err := q.DeQueue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Message{ID: idA, Payload: "a", Attempt: 1}, msgA)
+	assert.NoError(t, q.Ack(ctx, msgA))
+	assert.Equal(t, NewErrMessageNotFound, q.Ack(ctx, msgA))
+
+	// b 超过可见性超时时间仍未确认，被重新投递，之前的投递无法再确认消息
+	mr.SetTime(start.Add(5*time.Second + testVisible))
+	redelivered, err := q.tryDeQueue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Message{ID: idB, Payload: "b", Attempt: 2}, redelivered)
+	assert.Equal(t, NewErrMessageNotFound, q.Ack(ctx, msgB))
+	assert.NoError(t, q.Ack(ctx, redelivered))
+
+	_, err = q.tryDeQueue(ctx)
+	assert.Equal(t, redis.Nil, err)
+	for _, key := range []string{q.delayedKey, q.unackedKey, q.dataKey, q.attemptsKey} {
+		assert.False(t, mr.Exists(key), key)
+	}
+}
+
+func TestNewDelayQueue(t *testing.T) {
+	q := NewDelayQueue(nil, "orders")
+	assert.Equal(t, "{orders}:delayed", q.delayedKey)
+	assert.Equal(t, "{orders}:unacked", q.unackedKey)
+	assert.Equal(t, "{orders}:data", q.dataKey)
+	assert.Equal(t, "{orders}:attempts", q.attemptsKey)
+	assert.Equal(t, defaultVisibilityTimeout, q.visibilityTimeout)
+	assert.Equal(t, defaultPollInterval, q.pollInterval)
+	assert.Len(t, q.newID(), 32)
+	assert.NotEqual(t, q.newID(), q.newID())
+}
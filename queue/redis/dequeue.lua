@@ -0,0 +1,50 @@
+--[[
+    将到期的消息移动到未确认集合，并返回最早到期的一条消息
+    输入：
+    KEYS[1] - 延时集合（有序集合，score 为消息可以被消费的时间）
+    KEYS[2] - 未确认集合（有序集合，score 为消息的可见性超时时间）
+    KEYS[3] - 消息内容（哈希表，field 为消息 ID）
+    KEYS[4] - 投递次数（哈希表，field 为消息 ID）
+    ARGV[1] - 可见性超时时间（毫秒）
+    ARGV[2] - 单次最多重新投递的消息数量
+    输出：
+    {id, payload, attempt} - 取出的消息以及本次是第几次投递
+    nil                    - 没有到期的消息
+--]]
+
+local delayed = KEYS[1]
+local unacked = KEYS[2]
+local data = KEYS[3]
+local attempts = KEYS[4]
+
+local visibilityTimeout = tonumber(ARGV[1])
+local batch = tonumber(ARGV[2])
+
+-- 使用 Redis 服务器的时间，避免多个客户端之间的时钟偏差
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+-- 可见性超时后仍未确认的消息放回延时集合，立即可以被重新消费
+local expired = redis.call('ZRANGEBYSCORE', unacked, '-inf', now, 'LIMIT', 0, batch)
+for _, id in ipairs(expired) do
+    redis.call('ZREM', unacked, id)
+    redis.call('ZADD', delayed, now, id)
+end
+
+while true do
+    local ids = redis.call('ZRANGEBYSCORE', delayed, '-inf', now, 'LIMIT', 0, 1)
+    if #ids == 0 then
+        return false
+    end
+    local id = ids[1]
+    redis.call('ZREM', delayed, id)
+    local payload = redis.call('HGET', data, id)
+    if payload then
+        -- 每次投递都会增加投递次数，确认时需要携带本次的投递次数，过期的投递无法确认消息
+        local attempt = redis.call('HINCRBY', attempts, id, 1)
+        redis.call('ZADD', unacked, now + visibilityTimeout, id)
+        return {id, payload, tostring(attempt)}
+    end
+    -- 消息内容不存在说明数据已经被清理，丢弃该 ID 继续查找
+    redis.call('HDEL', attempts, id)
+end
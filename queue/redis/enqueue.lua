@@ -0,0 +1,26 @@
+--[[
+    将消息放入延时队列
+    输入：
+    KEYS[1] - 延时集合（有序集合，score 为消息可以被消费的时间）
+    KEYS[2] - 消息内容（哈希表，field 为消息 ID）
+    ARGV[1] - 消息 ID
+    ARGV[2] - 消息内容
+    ARGV[3] - 延时时间（毫秒）
+    输出：
+    1 - 入队成功
+--]]
+
+local delayed = KEYS[1]
+local data = KEYS[2]
+
+local id = ARGV[1]
+local payload = ARGV[2]
+local delay = tonumber(ARGV[3])
+
+-- 使用 Redis 服务器的时间，避免多个客户端之间的时钟偏差
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+redis.call('HSET', data, id, payload)
+redis.call('ZADD', delayed, now + delay, id)
+return 1
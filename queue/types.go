@@ -0,0 +1,47 @@
+// Package queue
+/**
+* @Project : GenericGo
+* @File    : types.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/25 10:02
+**/
+
+package queue
+
+import "context"
+
+// Queue 定义了队列的通用接口
+type Queue[T any] interface {
+	// EnQueue 将元素放入队列，如果队列已满，返回 errs.NewErrOutOfCapacity。
+	EnQueue(val T) error
+
+	// DeQueue 从队列中移除并返回队首元素，如果队列为空，返回 errs.NewErrEmptyQueue。
+	DeQueue() (T, error)
+
+	// Peek 返回队首元素，但不移除它，如果队列为空，返回 errs.NewErrEmptyQueue。
+	Peek() (T, error)
+
+	// Len 返回队列中元素的数量。
+	Len() int
+
+	// IsEmpty 返回队列是否为空。
+	IsEmpty() bool
+
+	// IsFull 返回队列是否已满，无界队列永远不会满。
+	IsFull() bool
+}
+
+// BlockingQueue 定义了阻塞队列的通用接口
+type BlockingQueue[T any] interface {
+	// EnQueue 将元素放入队列，如果队列已满，则阻塞直到有空位或者 ctx 结束。
+	// ctx 结束时返回 ctx.Err()。
+	EnQueue(ctx context.Context, val T) error
+
+	// DeQueue 从队列中移除并返回队首元素，如果队列为空，则阻塞直到有元素或者 ctx 结束。
+	// ctx 结束时返回 ctx.Err()。
+	DeQueue(ctx context.Context) (T, error)
+
+	// Len 返回队列中元素的数量。
+	Len() int
+}
@@ -0,0 +1,263 @@
+// Package set
+/**
+* @Project : GenericGo
+* @File    : bitset.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/10 10:05
+**/
+
+package set
+
+import (
+	"encoding"
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/iterator"
+)
+
+var (
+	_ Set[uint32]                = (*BitSet)(nil)
+	_ iterator.Iterable[uint32]  = (*BitSet)(nil)
+	_ encoding.BinaryMarshaler   = (*BitSet)(nil)
+	_ encoding.BinaryUnmarshaler = (*BitSet)(nil)
+)
+
+const wordBits = 64
+
+// BitSet 基于位图实现的 uint32 集合，第 i 位为1表示集合中包含 i。
+// 每个元素只占用1位，适合存储大量稠密的整数，例如连续分配的用户 ID；
+// 占用的内存取决于集合中最大的元素，元素稀疏时应当使用 RoaringBitmap。
+type BitSet struct {
+	words []uint64
+}
+
+// Set 将第 i 位置为1，即向 BitSet 中添加 i
+func (Self *BitSet) Set(i uint32) {
+	idx := int(i / wordBits)
+	if idx >= len(Self.words) {
+		Self.grow(idx + 1)
+	}
+	Self.words[idx] |= 1 << (i % wordBits)
+}
+
+// Clear 将第 i 位置为0，即从 BitSet 中删除 i
+func (Self *BitSet) Clear(i uint32) {
+	if idx := int(i / wordBits); idx < len(Self.words) {
+		Self.words[idx] &^= 1 << (i % wordBits)
+	}
+}
+
+// Test 判断第 i 位是否为1，即 BitSet 中是否包含 i
+func (Self *BitSet) Test(i uint32) bool {
+	idx := int(i / wordBits)
+	return idx < len(Self.words) && Self.words[idx]&(1<<(i%wordBits)) != 0
+}
+
+// Count 返回值为1的位的数量，即 BitSet 中的元素数量
+func (Self *BitSet) Count() int {
+	res := 0
+	for _, word := range Self.words {
+		res += bits.OnesCount64(word)
+	}
+	return res
+}
+
+// NextSetBit 返回大于等于 from 的第一个值为1的位，第二个返回值为 false 表示不存在
+func (Self *BitSet) NextSetBit(from uint32) (uint32, bool) {
+	idx := int(from / wordBits)
+	if idx >= len(Self.words) {
+		return 0, false
+	}
+	// 忽略第一个字中低于 from 的位
+	word := Self.words[idx] >> (from % wordBits)
+	if word != 0 {
+		return from + uint32(bits.TrailingZeros64(word)), true
+	}
+	for idx++; idx < len(Self.words); idx++ {
+		if Self.words[idx] != 0 {
+			return uint32(idx*wordBits + bits.TrailingZeros64(Self.words[idx])), true
+		}
+	}
+	return 0, false
+}
+
+// And 返回两个 BitSet 按位与的结果，即交集
+func (Self *BitSet) And(other *BitSet) *BitSet {
+	res := &BitSet{words: make([]uint64, min(len(Self.words), len(other.words)))}
+	for i := range res.words {
+		res.words[i] = Self.words[i] & other.words[i]
+	}
+	return res
+}
+
+// Or 返回两个 BitSet 按位或的结果，即并集
+func (Self *BitSet) Or(other *BitSet) *BitSet {
+	long, short := Self.words, other.words
+	if len(long) < len(short) {
+		long, short = short, long
+	}
+	res := &BitSet{words: make([]uint64, len(long))}
+	copy(res.words, long)
+	for i, word := range short {
+		res.words[i] |= word
+	}
+	return res
+}
+
+// Xor 返回两个 BitSet 按位异或的结果，即对称差集
+func (Self *BitSet) Xor(other *BitSet) *BitSet {
+	long, short := Self.words, other.words
+	if len(long) < len(short) {
+		long, short = short, long
+	}
+	res := &BitSet{words: make([]uint64, len(long))}
+	copy(res.words, long)
+	for i, word := range short {
+		res.words[i] ^= word
+	}
+	return res
+}
+
+// AndNot 返回 Self 中为1且 other 中为0的位，即差集
+func (Self *BitSet) AndNot(other *BitSet) *BitSet {
+	res := &BitSet{words: make([]uint64, len(Self.words))}
+	copy(res.words, Self.words)
+	for i := 0; i < min(len(res.words), len(other.words)); i++ {
+		res.words[i] &^= other.words[i]
+	}
+	return res
+}
+
+// Add 向 BitSet 中添加一个元素，等同于 Set
+func (Self *BitSet) Add(key uint32) {
+	Self.Set(key)
+}
+
+// AddKeys 向 BitSet 中添加一组元素
+func (Self *BitSet) AddKeys(keys []uint32) {
+	for _, key := range keys {
+		Self.Set(key)
+	}
+}
+
+// Remove 从 BitSet 中删除一个元素，等同于 Clear
+func (Self *BitSet) Remove(key uint32) {
+	Self.Clear(key)
+}
+
+// RemoveKeys 从 BitSet 中删除一组元素
+func (Self *BitSet) RemoveKeys(keys []uint32) {
+	for _, key := range keys {
+		Self.Clear(key)
+	}
+}
+
+// Contains 检查 BitSet 中是否包含某个元素，等同于 Test
+func (Self *BitSet) Contains(key uint32) bool {
+	return Self.Test(key)
+}
+
+// ContainsAny 检查 BitSet 中是否包含给定切片中的某个元素
+func (Self *BitSet) ContainsAny(keys []uint32) bool {
+	for _, key := range keys {
+		if Self.Test(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsAll 检查 BitSet 中是否包含给定切片中的所有元素
+func (Self *BitSet) ContainsAll(keys []uint32) bool {
+	for _, key := range keys {
+		if !Self.Test(key) {
+			return false
+		}
+	}
+	return true
+}
+
+// Size 返回 BitSet 中的元素数量，等同于 Count
+func (Self *BitSet) Size() int {
+	return Self.Count()
+}
+
+// Keys 按照从小到大的顺序返回集合中所有的元素
+func (Self *BitSet) Keys() []uint32 {
+	res := make([]uint32, 0, Self.Count())
+	for idx, word := range Self.words {
+		for word != 0 {
+			res = append(res, uint32(idx*wordBits+bits.TrailingZeros64(word)))
+			// 清除最低位的1
+			word &= word - 1
+		}
+	}
+	return res
+}
+
+// Iterator 返回按照从小到大的顺序遍历集合中所有元素的迭代器
+func (Self *BitSet) Iterator() iterator.Iterator[uint32] {
+	var next uint32
+	done := false
+	return iterator.FromFunc(func() (uint32, bool) {
+		if done {
+			return 0, false
+		}
+		val, ok := Self.NextSetBit(next)
+		// val 为 uint32 的最大值时 next 会溢出，需要单独结束遍历
+		if !ok || val == ^uint32(0) {
+			done = true
+		}
+		next = val + 1
+		return val, ok
+	})
+}
+
+// MarshalBinary 将 BitSet 编码为二进制数据。
+// 格式为按照小端序依次排列的64位字，末尾值为0的字会被省略。
+func (Self *BitSet) MarshalBinary() ([]byte, error) {
+	n := len(Self.words)
+	for n > 0 && Self.words[n-1] == 0 {
+		n--
+	}
+	res := make([]byte, 0, n*8)
+	for _, word := range Self.words[:n] {
+		res = binary.LittleEndian.AppendUint64(res, word)
+	}
+	return res, nil
+}
+
+// UnmarshalBinary 使用 MarshalBinary 编码的二进制数据覆盖 BitSet 的内容。
+// 如果数据的长度不是8的倍数，返回错误。
+func (Self *BitSet) UnmarshalBinary(data []byte) error {
+	if len(data)%8 != 0 {
+		return errs.NewErrInvalidBinaryData("the length of bitset data must be a multiple of 8")
+	}
+	words := make([]uint64, len(data)/8)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	Self.words = words
+	return nil
+}
+
+// grow 将 words 扩展到至少 n 个字，按照2倍扩容以均摊多次 Set 的开销
+func (Self *BitSet) grow(n int) {
+	if n <= cap(Self.words) {
+		Self.words = Self.words[:n]
+		return
+	}
+	words := make([]uint64, n, max(n, 2*cap(Self.words)))
+	copy(words, Self.words)
+	Self.words = words
+}
+
+// NewBitSet 创建一个空的 BitSet，nbits 为预先分配的位数，之后会按需扩容
+func NewBitSet(nbits int) *BitSet {
+	return &BitSet{
+		words: make([]uint64, 0, (max(nbits, 0)+wordBits-1)/wordBits),
+	}
+}
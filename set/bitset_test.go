@@ -0,0 +1,167 @@
+// Package set
+/**
+* @Project : GenericGo
+* @File    : bitset_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/10 11:20
+**/
+
+package set
+
+import (
+	"testing"
+
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/iterator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBitSetOf(keys ...uint32) *BitSet {
+	res := NewBitSet(0)
+	res.AddKeys(keys)
+	return res
+}
+
+func TestBitSet_SetClearTest(t *testing.T) {
+	tests := []struct {
+		name      string
+		setVals   []uint32
+		clearVals []uint32
+		wantKeys  []uint32
+	}{
+		{
+			name:     "Empty bitset",
+			wantKeys: []uint32{},
+		},
+		{
+			name:     "Set values across words",
+			setVals:  []uint32{130, 0, 63, 64, 1},
+			wantKeys: []uint32{0, 1, 63, 64, 130},
+		},
+		{
+			name:      "Clear set and unset values",
+			setVals:   []uint32{1, 2, 3},
+			clearVals: []uint32{2, 1000},
+			wantKeys:  []uint32{1, 3},
+		},
+		{
+			name:     "Set maximum value",
+			setVals:  []uint32{^uint32(0), 5},
+			wantKeys: []uint32{5, ^uint32(0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bs := NewBitSet(64)
+			bs.AddKeys(tt.setVals)
+			bs.RemoveKeys(tt.clearVals)
+			assert.Equal(t, tt.wantKeys, bs.Keys())
+			assert.Equal(t, tt.wantKeys, iterator.Collect(bs.Iterator()))
+			assert.Equal(t, len(tt.wantKeys), bs.Count())
+			assert.Equal(t, len(tt.wantKeys), bs.Size())
+			assert.True(t, bs.ContainsAll(tt.wantKeys))
+			assert.False(t, bs.ContainsAny(tt.clearVals))
+			for _, key := range tt.wantKeys {
+				assert.True(t, bs.Test(key))
+			}
+		})
+	}
+}
+
+func TestBitSet_NextSetBit(t *testing.T) {
+	bs := newBitSetOf(3, 64, 200)
+	tests := []struct {
+		from    uint32
+		wantVal uint32
+		wantOk  bool
+	}{
+		{from: 0, wantVal: 3, wantOk: true},
+		{from: 3, wantVal: 3, wantOk: true},
+		{from: 4, wantVal: 64, wantOk: true},
+		{from: 65, wantVal: 200, wantOk: true},
+		{from: 201, wantOk: false},
+		{from: 100000, wantOk: false},
+	}
+	for _, tt := range tests {
+		val, ok := bs.NextSetBit(tt.from)
+		assert.Equal(t, tt.wantOk, ok)
+		assert.Equal(t, tt.wantVal, val)
+	}
+}
+
+func TestBitSet_Operations(t *testing.T) {
+	tests := []struct {
+		name       string
+		keys1      []uint32
+		keys2      []uint32
+		wantAnd    []uint32
+		wantOr     []uint32
+		wantXor    []uint32
+		wantAndNot []uint32
+	}{
+		{
+			name:       "Same length",
+			keys1:      []uint32{1, 2, 3},
+			keys2:      []uint32{2, 3, 4},
+			wantAnd:    []uint32{2, 3},
+			wantOr:     []uint32{1, 2, 3, 4},
+			wantXor:    []uint32{1, 4},
+			wantAndNot: []uint32{1},
+		},
+		{
+			name:       "Different lengths",
+			keys1:      []uint32{1, 500},
+			keys2:      []uint32{1, 70},
+			wantAnd:    []uint32{1},
+			wantOr:     []uint32{1, 70, 500},
+			wantXor:    []uint32{70, 500},
+			wantAndNot: []uint32{500},
+		},
+		{
+			name:       "Empty operand",
+			keys1:      []uint32{},
+			keys2:      []uint32{1, 70},
+			wantAnd:    []uint32{},
+			wantOr:     []uint32{1, 70},
+			wantXor:    []uint32{1, 70},
+			wantAndNot: []uint32{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bs1, bs2 := newBitSetOf(tt.keys1...), newBitSetOf(tt.keys2...)
+			assert.Equal(t, tt.wantAnd, bs1.And(bs2).Keys())
+			assert.Equal(t, tt.wantOr, bs1.Or(bs2).Keys())
+			assert.Equal(t, tt.wantXor, bs1.Xor(bs2).Keys())
+			assert.Equal(t, tt.wantAndNot, bs1.AndNot(bs2).Keys())
+			// 参与运算的 BitSet 不会被修改
+			assert.Equal(t, tt.keys1, bs1.Keys())
+			assert.Equal(t, tt.keys2, bs2.Keys())
+		})
+	}
+}
+
+func TestBitSet_Binary(t *testing.T) {
+	bs := newBitSetOf(0, 65, 1000)
+	bs.Clear(1000)
+	data, err := bs.MarshalBinary()
+	require.NoError(t, err)
+	// 末尾值为0的字会被省略
+	assert.Len(t, data, 16)
+
+	decoded := NewBitSet(0)
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, []uint32{0, 65}, decoded.Keys())
+
+	data, err = NewBitSet(128).MarshalBinary()
+	require.NoError(t, err)
+	assert.Empty(t, data)
+
+	err = decoded.UnmarshalBinary([]byte{1, 2, 3})
+	assert.Equal(t, errs.NewErrInvalidBinaryData("the length of bitset data must be a multiple of 8"), err)
+	assert.Equal(t, []uint32{0, 65}, decoded.Keys())
+}
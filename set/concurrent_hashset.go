@@ -0,0 +1,272 @@
+// Package set
+/**
+* @Project : GenericGo
+* @File    : concurrent_hashset.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/9 15:20
+**/
+
+package set
+
+import (
+	"fmt"
+	"hash/maphash"
+	"math"
+	"math/bits"
+	"reflect"
+	"sync"
+
+	"github.com/HJH0924/GenericGo/iterator"
+	"github.com/HJH0924/GenericGo/option"
+)
+
+var (
+	_ Set[any]               = (*ConcurrentHashSet[any])(nil)
+	_ iterator.Iterable[any] = (*ConcurrentHashSet[any])(nil)
+)
+
+const defaultShardCount = 32
+
+// concurrentHashSetShard 是 ConcurrentHashSet 的一个分片，每个分片拥有独立的读写锁
+type concurrentHashSetShard[T comparable] struct {
+	rwLock sync.RWMutex
+	m      map[T]struct{}
+}
+
+// ConcurrentHashSet 并发安全的哈希集合。
+// 与使用一把锁保护整个集合不同，它采用锁分段（lock striping）的方式，按照元素的哈希值将元素分散到多个分片中，
+// 每个分片拥有独立的读写锁，访问不同分片的协程之间不会互相阻塞。
+//
+// Size、Keys 和 Iterator 需要依次访问所有分片，它们是弱一致的：结果中可能包含也可能不包含并发修改的元素。
+type ConcurrentHashSet[T comparable] struct {
+	shards     []*concurrentHashSetShard[T]
+	shardMask  uint64
+	shardCount int
+	hash       func(key T) uint64
+}
+
+// Add 向 ConcurrentHashSet 中添加一个元素
+func (Self *ConcurrentHashSet[T]) Add(key T) {
+	shard := Self.shardOf(key)
+	shard.rwLock.Lock()
+	defer shard.rwLock.Unlock()
+	shard.m[key] = struct{}{}
+}
+
+// AddKeys 向 ConcurrentHashSet 中添加一组元素
+func (Self *ConcurrentHashSet[T]) AddKeys(keys []T) {
+	for _, key := range keys {
+		Self.Add(key)
+	}
+}
+
+// Remove 从 ConcurrentHashSet 中删除一个元素
+func (Self *ConcurrentHashSet[T]) Remove(key T) {
+	shard := Self.shardOf(key)
+	shard.rwLock.Lock()
+	defer shard.rwLock.Unlock()
+	delete(shard.m, key)
+}
+
+// RemoveKeys 从 ConcurrentHashSet 中删除一组元素
+func (Self *ConcurrentHashSet[T]) RemoveKeys(keys []T) {
+	for _, key := range keys {
+		Self.Remove(key)
+	}
+}
+
+// Contains 检查 ConcurrentHashSet 中是否包含某个元素
+func (Self *ConcurrentHashSet[T]) Contains(key T) bool {
+	shard := Self.shardOf(key)
+	shard.rwLock.RLock()
+	defer shard.rwLock.RUnlock()
+	_, exists := shard.m[key]
+	return exists
+}
+
+// ContainsAny 检查 ConcurrentHashSet 中是否包含给定切片中的某个元素
+func (Self *ConcurrentHashSet[T]) ContainsAny(keys []T) bool {
+	for _, key := range keys {
+		if Self.Contains(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsAll 检查 ConcurrentHashSet 中是否包含给定切片中的所有元素
+func (Self *ConcurrentHashSet[T]) ContainsAll(keys []T) bool {
+	for _, key := range keys {
+		if !Self.Contains(key) {
+			return false
+		}
+	}
+	return true
+}
+
+// Size 返回 ConcurrentHashSet 中的元素数量
+func (Self *ConcurrentHashSet[T]) Size() int {
+	res := 0
+	for _, shard := range Self.shards {
+		shard.rwLock.RLock()
+		res += len(shard.m)
+		shard.rwLock.RUnlock()
+	}
+	return res
+}
+
+// Keys 返回集合中所有的元素
+// 返回的顺序不固定
+func (Self *ConcurrentHashSet[T]) Keys() []T {
+	res := make([]T, 0, Self.Size())
+	for _, shard := range Self.shards {
+		shard.rwLock.RLock()
+		for key := range shard.m {
+			res = append(res, key)
+		}
+		shard.rwLock.RUnlock()
+	}
+	return res
+}
+
+// Iterator 返回遍历集合中所有元素的迭代器，遍历的顺序不固定
+// 迭代器遍历的是调用时通过 Keys 得到的快照，遍历期间可以安全地修改 ConcurrentHashSet
+func (Self *ConcurrentHashSet[T]) Iterator() iterator.Iterator[T] {
+	return iterator.FromSlice(Self.Keys())
+}
+
+// ShardCount 返回 ConcurrentHashSet 的分片数量
+func (Self *ConcurrentHashSet[T]) ShardCount() int {
+	return Self.shardCount
+}
+
+// shardOf 返回 key 所在的分片
+func (Self *ConcurrentHashSet[T]) shardOf(key T) *concurrentHashSetShard[T] {
+	return Self.shards[Self.hash(key)&Self.shardMask]
+}
+
+// newDefaultHasher 返回默认的哈希函数
+// 基础类型以及底层类型为基础类型的自定义类型（例如 type UserID int64）会直接计算哈希值，
+// 结构体、数组等复合类型会先格式化为字符串，速度较慢，此时建议通过 WithHasher 指定哈希函数
+func newDefaultHasher[T comparable]() func(key T) uint64 {
+	seed := maphash.MakeSeed()
+	return func(key T) uint64 {
+		switch k := any(key).(type) {
+		case string:
+			return maphash.String(seed, k)
+		case int:
+			return mixUint64(uint64(k))
+		case int8:
+			return mixUint64(uint64(k))
+		case int16:
+			return mixUint64(uint64(k))
+		case int32:
+			return mixUint64(uint64(k))
+		case int64:
+			return mixUint64(uint64(k))
+		case uint:
+			return mixUint64(uint64(k))
+		case uint8:
+			return mixUint64(uint64(k))
+		case uint16:
+			return mixUint64(uint64(k))
+		case uint32:
+			return mixUint64(uint64(k))
+		case uint64:
+			return mixUint64(k)
+		case uintptr:
+			return mixUint64(uint64(k))
+		case bool:
+			if k {
+				return 1
+			}
+			return 0
+		case float32:
+			return hashFloat64(float64(k))
+		case float64:
+			return hashFloat64(k)
+		default:
+			return hashByKind(seed, key)
+		}
+	}
+}
+
+// hashByKind 按照底层类型计算自定义类型的哈希值，复合类型则格式化为字符串之后再计算
+func hashByKind(seed maphash.Seed, key any) uint64 {
+	v := reflect.ValueOf(key)
+	switch v.Kind() {
+	case reflect.String:
+		return maphash.String(seed, v.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return mixUint64(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return mixUint64(v.Uint())
+	case reflect.Bool:
+		if v.Bool() {
+			return 1
+		}
+		return 0
+	case reflect.Float32, reflect.Float64:
+		return hashFloat64(v.Float())
+	default:
+		// 相等的值格式化之后的字符串相同，%#v 会带上类型信息，避免不同动态类型的值总是落在同一个分片
+		return maphash.String(seed, fmt.Sprintf("%#v", key))
+	}
+}
+
+// hashFloat64 计算浮点数的哈希值，+0 和 -0 相等，因此需要得到相同的哈希值
+func hashFloat64(f float64) uint64 {
+	if f == 0 {
+		return 0
+	}
+	return mixUint64(math.Float64bits(f))
+}
+
+// mixUint64 打散整数的二进制位，避免连续的整数只使用低位而落在相邻的分片中（splitmix64 的终结步骤）
+func mixUint64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// NewConcurrentHashSet 创建一个空的 ConcurrentHashSet，默认拥有 32 个分片。
+func NewConcurrentHashSet[T comparable](opts ...option.Option[ConcurrentHashSet[T]]) *ConcurrentHashSet[T] {
+	res := &ConcurrentHashSet[T]{
+		shardCount: defaultShardCount,
+	}
+	option.Apply(res, opts...)
+	if res.hash == nil {
+		res.hash = newDefaultHasher[T]()
+	}
+	res.shards = make([]*concurrentHashSetShard[T], res.shardCount)
+	for i := range res.shards {
+		res.shards[i] = &concurrentHashSetShard[T]{
+			m: make(map[T]struct{}),
+		}
+	}
+	res.shardMask = uint64(res.shardCount - 1)
+	return res
+}
+
+// WithShardCount 设置分片的数量，会向上取整为2的幂。小于1的值会被忽略。
+// 分片越多，并发写入时的冲突越少，但是 Size 和 Keys 需要访问的分片也越多。
+func WithShardCount[T comparable](shardCount int) option.Option[ConcurrentHashSet[T]] {
+	return func(s *ConcurrentHashSet[T]) {
+		if shardCount >= 1 {
+			s.shardCount = 1 << bits.Len(uint(shardCount-1))
+		}
+	}
+}
+
+// WithHasher 设置计算元素哈希值的函数，相等的元素必须得到相同的哈希值。
+// 元素不是基础类型（例如结构体）时，建议指定哈希函数以避免默认实现中格式化字符串的开销；
+// 如果结构体中包含浮点数字段，默认实现会将相等的 +0 和 -0 格式化为不同的字符串，此时必须指定哈希函数。
+func WithHasher[T comparable](hash func(key T) uint64) option.Option[ConcurrentHashSet[T]] {
+	return func(s *ConcurrentHashSet[T]) {
+		s.hash = hash
+	}
+}
@@ -0,0 +1,221 @@
+// Package set
+/**
+* @Project : GenericGo
+* @File    : concurrent_hashset_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/9 16:40
+**/
+
+package set
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/HJH0924/GenericGo/iterator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentHashSet_AddRemove(t *testing.T) {
+	tests := []struct {
+		name       string
+		addVals    []int
+		removeVals []int
+		wantKeys   []int
+	}{
+		{
+			name:       "Add duplicate values",
+			addVals:    []int{1, 1, 2, 2, 3, 3},
+			removeVals: nil,
+			wantKeys:   []int{1, 2, 3},
+		},
+		{
+			name:       "Remove existing and missing values",
+			addVals:    []int{1, 2, 3},
+			removeVals: []int{2, 4},
+			wantKeys:   []int{1, 3},
+		},
+		{
+			name:       "Remove all values",
+			addVals:    []int{1, 2, 3},
+			removeVals: []int{1, 2, 3},
+			wantKeys:   []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewConcurrentHashSet[int]()
+			s.AddKeys(tt.addVals)
+			s.RemoveKeys(tt.removeVals)
+			assert.ElementsMatch(t, tt.wantKeys, s.Keys())
+			assert.Equal(t, len(tt.wantKeys), s.Size())
+			assert.ElementsMatch(t, tt.wantKeys, iterator.Collect(s.Iterator()))
+			assert.True(t, s.ContainsAll(tt.wantKeys))
+			assert.False(t, s.ContainsAny(tt.removeVals))
+		})
+	}
+}
+
+func TestConcurrentHashSet_Contains(t *testing.T) {
+	s := NewConcurrentHashSet[any]()
+	s.AddKeys([]any{"a", 1, int64(1), 2.5, true, struct{ X int }{X: 1}})
+	assert.True(t, s.Contains("a"))
+	assert.True(t, s.Contains(1))
+	assert.True(t, s.Contains(int64(1)))
+	assert.False(t, s.Contains(int32(1)))
+	assert.True(t, s.Contains(2.5))
+	assert.True(t, s.Contains(true))
+	assert.False(t, s.Contains(false))
+	assert.True(t, s.Contains(struct{ X int }{X: 1}))
+	assert.False(t, s.Contains(struct{ X int }{X: 2}))
+	assert.Equal(t, 6, s.Size())
+
+	// +0 和 -0 是相等的元素
+	floats := NewConcurrentHashSet[float64]()
+	floats.Add(0)
+	assert.True(t, floats.Contains(math.Copysign(0, -1)))
+
+	// 自定义类型按照底层类型计算哈希值
+	ids := NewConcurrentHashSet[userID]()
+	ids.AddKeys([]userID{1, 2, 3})
+	assert.True(t, ids.Contains(2))
+	assert.False(t, ids.Contains(4))
+	ids.Remove(2)
+	assert.False(t, ids.Contains(2))
+	assert.Equal(t, 2, ids.Size())
+
+	names := NewConcurrentHashSet[any]()
+	names.AddKeys([]any{userName("a"), "a"})
+	assert.True(t, names.Contains(userName("a")))
+	assert.False(t, names.Contains(userName("b")))
+	assert.Equal(t, 2, names.Size())
+}
+
+func TestConcurrentHashSet_Options(t *testing.T) {
+	tests := []struct {
+		name           string
+		shardCount     int
+		wantShardCount int
+	}{
+		{
+			name:           "Default shard count",
+			shardCount:     0,
+			wantShardCount: defaultShardCount,
+		},
+		{
+			name:           "Power of two",
+			shardCount:     8,
+			wantShardCount: 8,
+		},
+		{
+			name:           "Round up to power of two",
+			shardCount:     5,
+			wantShardCount: 8,
+		},
+		{
+			name:           "Single shard",
+			shardCount:     1,
+			wantShardCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewConcurrentHashSet[int](WithShardCount[int](tt.shardCount))
+			assert.Equal(t, tt.wantShardCount, s.ShardCount())
+			s.AddKeys([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+			assert.Equal(t, 10, s.Size())
+		})
+	}
+
+	type point struct {
+		x, y int
+	}
+	s := NewConcurrentHashSet[point](WithHasher(func(p point) uint64 {
+		return uint64(p.x*31 + p.y)
+	}))
+	s.Add(point{1, 2})
+	assert.True(t, s.Contains(point{1, 2}))
+	assert.False(t, s.Contains(point{2, 1}))
+}
+
+func TestConcurrentHashSet_Concurrent(t *testing.T) {
+	s := NewConcurrentHashSet[string]()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				key := strconv.Itoa(j)
+				s.Add(key)
+				assert.True(t, s.Contains(key))
+				if j%2 == i%2 {
+					s.Remove(key)
+				}
+				_ = s.Size()
+			}
+		}(i)
+	}
+	wg.Wait()
+	for j := 0; j < 1000; j++ {
+		s.Remove(strconv.Itoa(j))
+	}
+	assert.Equal(t, 0, s.Size())
+}
+
+func BenchmarkConcurrentHashSet(b *testing.B) {
+	s := NewConcurrentHashSet[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		// 每个协程从不同的位置开始，避免所有协程总是访问相同的元素
+		i := rand.Int()
+		for pb.Next() {
+			s.Add(i & 4095)
+			s.Contains((i + 1) & 4095)
+			i++
+		}
+	})
+}
+
+type (
+	userID   int64
+	userName string
+)
+
+// BenchmarkConcurrentHashSet_NamedInt 元素为自定义的整数类型，默认的哈希函数按照底层类型计算哈希值
+func BenchmarkConcurrentHashSet_NamedInt(b *testing.B) {
+	s := NewConcurrentHashSet[userID]()
+	b.RunParallel(func(pb *testing.PB) {
+		// 每个协程从不同的位置开始，避免所有协程总是访问相同的元素
+		i := rand.Int()
+		for pb.Next() {
+			s.Add(userID(i & 4095))
+			s.Contains(userID((i + 1) & 4095))
+			i++
+		}
+	})
+}
+
+// BenchmarkHashSet_SingleLock 作为对照，使用一把读写锁保护整个 HashSet
+func BenchmarkHashSet_SingleLock(b *testing.B) {
+	s := NewHashSet[int]()
+	var rwLock sync.RWMutex
+	b.RunParallel(func(pb *testing.PB) {
+		// 每个协程从不同的位置开始，避免所有协程总是访问相同的元素
+		i := rand.Int()
+		for pb.Next() {
+			rwLock.Lock()
+			s.Add(i & 4095)
+			rwLock.Unlock()
+			rwLock.RLock()
+			s.Contains((i + 1) & 4095)
+			rwLock.RUnlock()
+			i++
+		}
+	})
+}
@@ -9,10 +9,17 @@
 
 package set
 
+import (
+	"reflect"
+
+	"github.com/HJH0924/GenericGo/iterator"
+)
+
 var (
 	// 参考：
 	// https://github.com/xxjwxc/uber_go_guide_cn?tab=readme-ov-file#interface-%E5%90%88%E7%90%86%E6%80%A7%E9%AA%8C%E8%AF%81
-	_ Set[any] = (*HashSet[any])(nil)
+	_ Set[any]               = (*HashSet[any])(nil)
+	_ iterator.Iterable[any] = (*HashSet[any])(nil)
 )
 
 // HashSet 基于 map 实现的哈希集合
@@ -85,6 +92,23 @@ func (Self *HashSet[T]) Keys() []T {
 	return res
 }
 
+// Iterator 返回遍历集合中所有元素的迭代器，遍历的顺序不固定
+// 与 Keys 不同，迭代器不会一次性复制所有元素，而是在遍历时逐个读取，
+// 因此遍历期间修改 HashSet 的效果与在 for range 中修改 map 相同
+func (Self *HashSet[T]) Iterator() iterator.Iterator[T] {
+	// reflect.MapIter 可以逐个推进 map 的遍历，而 for range 无法在中途暂停
+	mapIter := reflect.ValueOf(Self.m).MapRange()
+	return iterator.FromFunc(func() (T, bool) {
+		if !mapIter.Next() {
+			var zero T
+			return zero, false
+		}
+		// T 为接口类型时 nil 键的 Interface() 返回 nil，此时断言失败，得到的零值即为 nil
+		key, _ := mapIter.Key().Interface().(T)
+		return key, true
+	})
+}
+
 func NewHashSet[T comparable]() *HashSet[T] {
 	return &HashSet[T]{
 		m: make(map[T]struct{}),
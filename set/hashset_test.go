@@ -12,8 +12,10 @@ package set
 import (
 	"testing"
 
+	"github.com/HJH0924/GenericGo/iterator"
 	"github.com/HJH0924/GenericGo/slice"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHashSet_AddKeys(t *testing.T) {
@@ -290,3 +292,53 @@ func TestHashSet_Keys(t *testing.T) {
 		})
 	}
 }
+
+func TestHashSet_Iterator(t *testing.T) {
+	tests := []struct {
+		name     string
+		vals     []int
+		wantVals []int
+	}{
+		{
+			name:     "Empty set",
+			vals:     []int{},
+			wantVals: []int{},
+		},
+		{
+			name:     "Multiple values",
+			vals:     []int{3, 1, 2, 3},
+			wantVals: []int{1, 2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewHashSet[int]()
+			s.AddKeys(tt.vals)
+			assert.ElementsMatch(t, tt.wantVals, iterator.Collect(s.Iterator()))
+		})
+	}
+
+	// 元素类型为接口时，集合中可以包含 nil
+	s := NewHashSet[any]()
+	s.AddKeys([]any{nil, 1, "a"})
+	assert.ElementsMatch(t, []any{nil, 1, "a"}, iterator.Collect(s.Iterator()))
+}
+
+func TestHashSet_IteratorLazy(t *testing.T) {
+	s := NewHashSet[int]()
+	for i := 0; i < 100; i++ {
+		s.Add(i)
+	}
+
+	it := s.Iterator()
+	require.True(t, it.Next())
+	first := it.Value()
+	// 迭代器不是快照，尚未遍历到的元素被删除后不会再出现
+	for i := 0; i < 100; i++ {
+		if i != first {
+			s.Remove(i)
+		}
+	}
+	assert.False(t, it.Next())
+}
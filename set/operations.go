@@ -0,0 +1,77 @@
+// Package set
+/**
+* @Project : GenericGo
+* @File    : operations.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/9 16:05
+**/
+
+package set
+
+// 以下集合运算适用于任意 Set 的实现，参数可以是不同的实现，例如 HashSet 和 TreeSet。
+// 运算结果总是一个新的 HashSet，不会修改参与运算的集合。
+
+// Union 计算两个集合的并集，返回一个包含两个集合中所有元素的新 HashSet。
+func Union[T comparable](s1, s2 Set[T]) *HashSet[T] {
+	res := NewHashSetWithCap[T](s1.Size() + s2.Size())
+	res.AddKeys(s1.Keys())
+	res.AddKeys(s2.Keys())
+	return res
+}
+
+// Intersection 计算两个集合的交集，返回一个只包含同时存在于两个集合中的元素的新 HashSet。
+// 只会遍历较小的集合。
+func Intersection[T comparable](s1, s2 Set[T]) *HashSet[T] {
+	if s1.Size() > s2.Size() {
+		s1, s2 = s2, s1
+	}
+	res := NewHashSet[T]()
+	for _, key := range s1.Keys() {
+		if s2.Contains(key) {
+			res.Add(key)
+		}
+	}
+	return res
+}
+
+// Difference 计算两个集合的差集，返回一个包含存在于 s1 但不存在于 s2 中的元素的新 HashSet。
+func Difference[T comparable](s1, s2 Set[T]) *HashSet[T] {
+	res := NewHashSet[T]()
+	for _, key := range s1.Keys() {
+		if !s2.Contains(key) {
+			res.Add(key)
+		}
+	}
+	return res
+}
+
+// SymmetricDifference 计算两个集合的对称差集，返回一个包含只存在于其中一个集合中的元素的新 HashSet。
+func SymmetricDifference[T comparable](s1, s2 Set[T]) *HashSet[T] {
+	res := Difference(s1, s2)
+	for _, key := range s2.Keys() {
+		if !s1.Contains(key) {
+			res.Add(key)
+		}
+	}
+	return res
+}
+
+// IsSubset 判断 s1 是否为 s2 的子集，即 s1 中的所有元素都存在于 s2 中。
+// 空集是任何集合的子集。
+func IsSubset[T comparable](s1, s2 Set[T]) bool {
+	if s1.Size() > s2.Size() {
+		return false
+	}
+	return s2.ContainsAll(s1.Keys())
+}
+
+// IsSuperset 判断 s1 是否为 s2 的超集，即 s2 中的所有元素都存在于 s1 中。
+func IsSuperset[T comparable](s1, s2 Set[T]) bool {
+	return IsSubset(s2, s1)
+}
+
+// Equal 判断两个集合是否包含完全相同的元素。
+func Equal[T comparable](s1, s2 Set[T]) bool {
+	return s1.Size() == s2.Size() && IsSubset(s1, s2)
+}
@@ -0,0 +1,131 @@
+// Package set
+/**
+* @Project : GenericGo
+* @File    : operations_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/9 17:10
+**/
+
+package set
+
+import (
+	"cmp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newSets 返回使用相同元素构造的不同 Set 实现
+func newSets(keys []int) map[string]Set[int] {
+	hashSet := NewHashSet[int]()
+	hashSet.AddKeys(keys)
+	treeSet := NewTreeSet[int](cmp.Compare[int])
+	treeSet.AddKeys(keys)
+	concurrentSet := NewConcurrentHashSet[int]()
+	concurrentSet.AddKeys(keys)
+	return map[string]Set[int]{
+		"HashSet":           hashSet,
+		"TreeSet":           treeSet,
+		"ConcurrentHashSet": concurrentSet,
+	}
+}
+
+func TestOperations(t *testing.T) {
+	tests := []struct {
+		name             string
+		keys1            []int
+		keys2            []int
+		wantUnion        []int
+		wantIntersection []int
+		wantDifference   []int
+		wantSymDiff      []int
+		wantSubset       bool
+		wantSuperset     bool
+		wantEqual        bool
+	}{
+		{
+			name:             "Overlapping sets",
+			keys1:            []int{1, 2, 3, 4},
+			keys2:            []int{3, 4, 5},
+			wantUnion:        []int{1, 2, 3, 4, 5},
+			wantIntersection: []int{3, 4},
+			wantDifference:   []int{1, 2},
+			wantSymDiff:      []int{1, 2, 5},
+		},
+		{
+			name:             "Disjoint sets",
+			keys1:            []int{1, 2},
+			keys2:            []int{3},
+			wantUnion:        []int{1, 2, 3},
+			wantIntersection: []int{},
+			wantDifference:   []int{1, 2},
+			wantSymDiff:      []int{1, 2, 3},
+		},
+		{
+			name:             "Subset",
+			keys1:            []int{1, 2},
+			keys2:            []int{1, 2, 3},
+			wantUnion:        []int{1, 2, 3},
+			wantIntersection: []int{1, 2},
+			wantDifference:   []int{},
+			wantSymDiff:      []int{3},
+			wantSubset:       true,
+		},
+		{
+			name:             "Superset",
+			keys1:            []int{1, 2, 3},
+			keys2:            []int{2},
+			wantUnion:        []int{1, 2, 3},
+			wantIntersection: []int{2},
+			wantDifference:   []int{1, 3},
+			wantSymDiff:      []int{1, 3},
+			wantSuperset:     true,
+		},
+		{
+			name:             "Equal sets",
+			keys1:            []int{1, 2, 3},
+			keys2:            []int{3, 2, 1},
+			wantUnion:        []int{1, 2, 3},
+			wantIntersection: []int{1, 2, 3},
+			wantDifference:   []int{},
+			wantSymDiff:      []int{},
+			wantSubset:       true,
+			wantSuperset:     true,
+			wantEqual:        true,
+		},
+		{
+			name:             "Empty sets",
+			keys1:            []int{},
+			keys2:            []int{},
+			wantUnion:        []int{},
+			wantIntersection: []int{},
+			wantDifference:   []int{},
+			wantSymDiff:      []int{},
+			wantSubset:       true,
+			wantSuperset:     true,
+			wantEqual:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		// 两个参数使用不同的 Set 实现组合
+		for name1, s1 := range newSets(tt.keys1) {
+			for name2, s2 := range newSets(tt.keys2) {
+				t.Run(tt.name+" "+name1+" "+name2, func(t *testing.T) {
+					assert.ElementsMatch(t, tt.wantUnion, Union(s1, s2).Keys())
+					assert.ElementsMatch(t, tt.wantIntersection, Intersection(s1, s2).Keys())
+					assert.ElementsMatch(t, tt.wantDifference, Difference(s1, s2).Keys())
+					assert.ElementsMatch(t, tt.wantSymDiff, SymmetricDifference(s1, s2).Keys())
+					assert.Equal(t, tt.wantSubset, IsSubset(s1, s2))
+					assert.Equal(t, tt.wantSuperset, IsSuperset(s1, s2))
+					assert.Equal(t, tt.wantEqual, Equal(s1, s2))
+
+					// 参与运算的集合不会被修改
+					assert.ElementsMatch(t, tt.keys1, s1.Keys())
+					assert.ElementsMatch(t, tt.keys2, s2.Keys())
+				})
+			}
+		}
+	}
+}
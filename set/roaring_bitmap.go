@@ -0,0 +1,378 @@
+// Package set
+/**
+* @Project : GenericGo
+* @File    : roaring_bitmap.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/10 14:30
+**/
+
+package set
+
+import (
+	"encoding"
+	"encoding/binary"
+	"math/bits"
+	"slices"
+
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/iterator"
+)
+
+var (
+	_ Set[uint32]                = (*RoaringBitmap)(nil)
+	_ iterator.Iterable[uint32]  = (*RoaringBitmap)(nil)
+	_ encoding.BinaryMarshaler   = (*RoaringBitmap)(nil)
+	_ encoding.BinaryUnmarshaler = (*RoaringBitmap)(nil)
+)
+
+const (
+	// arrayContainerMaxSize 数组容器最多存储的元素数量
+	// 超过之后数组容器占用的内存（每个元素2字节）会超过位图容器的 8KB，此时转换为位图容器
+	arrayContainerMaxSize = 4096
+	bitmapContainerWords  = 1 << 16 / wordBits
+)
+
+// roaringContainer 存储高16位相同的元素的低16位
+type roaringContainer interface {
+	// add 添加 low，返回添加之后的容器（可能发生了转换）以及 low 原来是否不存在
+	add(low uint16) (roaringContainer, bool)
+	// remove 删除 low，返回删除之后的容器（可能发生了转换）以及 low 原来是否存在
+	remove(low uint16) (roaringContainer, bool)
+	contains(low uint16) bool
+	cardinality() int
+	// appendTo 按照从小到大的顺序将容器中的元素与高16位 high 组合之后追加到 res 中
+	appendTo(res []uint32, high uint32) []uint32
+}
+
+// arrayContainer 使用有序数组存储元素，适合元素较少的情况
+type arrayContainer struct {
+	vals []uint16
+}
+
+func (c *arrayContainer) add(low uint16) (roaringContainer, bool) {
+	idx, found := slices.BinarySearch(c.vals, low)
+	if found {
+		return c, false
+	}
+	if len(c.vals) >= arrayContainerMaxSize {
+		res := c.toBitmap()
+		res.add(low)
+		return res, true
+	}
+	c.vals = slices.Insert(c.vals, idx, low)
+	return c, true
+}
+
+func (c *arrayContainer) remove(low uint16) (roaringContainer, bool) {
+	idx, found := slices.BinarySearch(c.vals, low)
+	if !found {
+		return c, false
+	}
+	c.vals = slices.Delete(c.vals, idx, idx+1)
+	return c, true
+}
+
+func (c *arrayContainer) contains(low uint16) bool {
+	_, found := slices.BinarySearch(c.vals, low)
+	return found
+}
+
+func (c *arrayContainer) cardinality() int {
+	return len(c.vals)
+}
+
+func (c *arrayContainer) appendTo(res []uint32, high uint32) []uint32 {
+	for _, low := range c.vals {
+		res = append(res, high|uint32(low))
+	}
+	return res
+}
+
+// toBitmap 将数组容器转换为位图容器
+func (c *arrayContainer) toBitmap() *bitmapContainer {
+	res := &bitmapContainer{}
+	for _, low := range c.vals {
+		res.words[low/wordBits] |= 1 << (low % wordBits)
+	}
+	res.card = len(c.vals)
+	return res
+}
+
+// bitmapContainer 使用 65536 位的位图存储元素，适合元素较多的情况
+type bitmapContainer struct {
+	words [bitmapContainerWords]uint64
+	card  int
+}
+
+func (c *bitmapContainer) add(low uint16) (roaringContainer, bool) {
+	mask := uint64(1) << (low % wordBits)
+	if c.words[low/wordBits]&mask != 0 {
+		return c, false
+	}
+	c.words[low/wordBits] |= mask
+	c.card++
+	return c, true
+}
+
+func (c *bitmapContainer) remove(low uint16) (roaringContainer, bool) {
+	mask := uint64(1) << (low % wordBits)
+	if c.words[low/wordBits]&mask == 0 {
+		return c, false
+	}
+	c.words[low/wordBits] &^= mask
+	c.card--
+	if c.card <= arrayContainerMaxSize {
+		return c.toArray(), true
+	}
+	return c, true
+}
+
+func (c *bitmapContainer) contains(low uint16) bool {
+	return c.words[low/wordBits]&(1<<(low%wordBits)) != 0
+}
+
+func (c *bitmapContainer) cardinality() int {
+	return c.card
+}
+
+func (c *bitmapContainer) appendTo(res []uint32, high uint32) []uint32 {
+	for idx, word := range c.words {
+		for word != 0 {
+			res = append(res, high|uint32(idx*wordBits+bits.TrailingZeros64(word)))
+			word &= word - 1
+		}
+	}
+	return res
+}
+
+// toArray 将位图容器转换为数组容器
+func (c *bitmapContainer) toArray() *arrayContainer {
+	res := &arrayContainer{vals: make([]uint16, 0, c.card)}
+	for idx, word := range c.words {
+		for word != 0 {
+			res.vals = append(res.vals, uint16(idx*wordBits+bits.TrailingZeros64(word)))
+			word &= word - 1
+		}
+	}
+	return res
+}
+
+// RoaringBitmap 压缩位图，参考 Roaring Bitmap 的设计实现的 uint32 集合。
+// 元素按照高16位分桶，每个桶使用一个容器存储低16位：
+//   - 元素不超过 4096 个时使用有序数组，每个元素占用2字节；
+//   - 元素超过 4096 个时使用 8KB 的位图，每个元素占用1位。
+//
+// 与 BitSet 相比，RoaringBitmap 只为实际存在元素的桶分配内存，适合存储稀疏或者跨度很大的整数集合。
+type RoaringBitmap struct {
+	keys       []uint16 // 有序的高16位
+	containers []roaringContainer
+	size       int
+}
+
+// Add 向 RoaringBitmap 中添加一个元素
+func (Self *RoaringBitmap) Add(key uint32) {
+	high, low := uint16(key>>16), uint16(key)
+	idx, found := slices.BinarySearch(Self.keys, high)
+	if !found {
+		Self.keys = slices.Insert(Self.keys, idx, high)
+		Self.containers = slices.Insert(Self.containers, idx, roaringContainer(&arrayContainer{}))
+	}
+	var added bool
+	Self.containers[idx], added = Self.containers[idx].add(low)
+	if added {
+		Self.size++
+	}
+}
+
+// AddKeys 向 RoaringBitmap 中添加一组元素
+func (Self *RoaringBitmap) AddKeys(keys []uint32) {
+	for _, key := range keys {
+		Self.Add(key)
+	}
+}
+
+// Remove 从 RoaringBitmap 中删除一个元素
+func (Self *RoaringBitmap) Remove(key uint32) {
+	high, low := uint16(key>>16), uint16(key)
+	idx, found := slices.BinarySearch(Self.keys, high)
+	if !found {
+		return
+	}
+	var removed bool
+	Self.containers[idx], removed = Self.containers[idx].remove(low)
+	if !removed {
+		return
+	}
+	Self.size--
+	// 删除空的容器
+	if Self.containers[idx].cardinality() == 0 {
+		Self.keys = slices.Delete(Self.keys, idx, idx+1)
+		Self.containers = slices.Delete(Self.containers, idx, idx+1)
+	}
+}
+
+// RemoveKeys 从 RoaringBitmap 中删除一组元素
+func (Self *RoaringBitmap) RemoveKeys(keys []uint32) {
+	for _, key := range keys {
+		Self.Remove(key)
+	}
+}
+
+// Contains 检查 RoaringBitmap 中是否包含某个元素
+func (Self *RoaringBitmap) Contains(key uint32) bool {
+	idx, found := slices.BinarySearch(Self.keys, uint16(key>>16))
+	return found && Self.containers[idx].contains(uint16(key))
+}
+
+// ContainsAny 检查 RoaringBitmap 中是否包含给定切片中的某个元素
+func (Self *RoaringBitmap) ContainsAny(keys []uint32) bool {
+	for _, key := range keys {
+		if Self.Contains(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsAll 检查 RoaringBitmap 中是否包含给定切片中的所有元素
+func (Self *RoaringBitmap) ContainsAll(keys []uint32) bool {
+	for _, key := range keys {
+		if !Self.Contains(key) {
+			return false
+		}
+	}
+	return true
+}
+
+// Size 返回 RoaringBitmap 中的元素数量
+func (Self *RoaringBitmap) Size() int {
+	return Self.size
+}
+
+// Keys 按照从小到大的顺序返回集合中所有的元素
+func (Self *RoaringBitmap) Keys() []uint32 {
+	res := make([]uint32, 0, Self.size)
+	for i, c := range Self.containers {
+		res = c.appendTo(res, uint32(Self.keys[i])<<16)
+	}
+	return res
+}
+
+// Iterator 返回按照从小到大的顺序遍历集合中所有元素的迭代器
+// 迭代器每次只展开一个容器中的元素，而不是一次性复制整个集合
+func (Self *RoaringBitmap) Iterator() iterator.Iterator[uint32] {
+	next := 0
+	var buf []uint32
+	return iterator.FromFunc(func() (uint32, bool) {
+		for len(buf) == 0 {
+			if next >= len(Self.containers) {
+				return 0, false
+			}
+			buf = Self.containers[next].appendTo(buf[:0], uint32(Self.keys[next])<<16)
+			next++
+		}
+		val := buf[0]
+		buf = buf[1:]
+		return val, true
+	})
+}
+
+// MarshalBinary 将 RoaringBitmap 编码为二进制数据，所有整数都使用小端序。
+// 格式为：4字节的容器数量，之后依次是每个容器的2字节高16位、4字节元素数量以及容器的内容。
+// 元素数量不超过 4096 的容器按照从小到大的顺序存储每个2字节的元素，否则存储 8KB 的位图。
+func (Self *RoaringBitmap) MarshalBinary() ([]byte, error) {
+	res := make([]byte, 0, 4+len(Self.containers)*6+Self.size*2)
+	res = binary.LittleEndian.AppendUint32(res, uint32(len(Self.containers)))
+	for i, c := range Self.containers {
+		res = binary.LittleEndian.AppendUint16(res, Self.keys[i])
+		res = binary.LittleEndian.AppendUint32(res, uint32(c.cardinality()))
+		switch c := c.(type) {
+		case *arrayContainer:
+			for _, low := range c.vals {
+				res = binary.LittleEndian.AppendUint16(res, low)
+			}
+		case *bitmapContainer:
+			for _, word := range c.words {
+				res = binary.LittleEndian.AppendUint64(res, word)
+			}
+		}
+	}
+	return res, nil
+}
+
+// UnmarshalBinary 使用 MarshalBinary 编码的二进制数据覆盖 RoaringBitmap 的内容。
+// 如果数据不完整或者不合法，返回错误，此时 RoaringBitmap 的内容不会被修改。
+func (Self *RoaringBitmap) UnmarshalBinary(data []byte) error {
+	if len(data) < 4 {
+		return errs.NewErrInvalidBinaryData("missing container count")
+	}
+	n := int(binary.LittleEndian.Uint32(data))
+	data = data[4:]
+	// 每个容器至少占用6字节，避免根据不合法的容器数量分配过多的内存
+	if n > len(data)/6 {
+		return errs.NewErrInvalidBinaryData("container count exceeds data length")
+	}
+	res := RoaringBitmap{
+		keys:       make([]uint16, 0, n),
+		containers: make([]roaringContainer, 0, n),
+	}
+	for i := 0; i < n; i++ {
+		if len(data) < 6 {
+			return errs.NewErrInvalidBinaryData("truncated container header")
+		}
+		key := binary.LittleEndian.Uint16(data)
+		card := int(binary.LittleEndian.Uint32(data[2:]))
+		data = data[6:]
+		if i > 0 && key <= res.keys[i-1] {
+			return errs.NewErrInvalidBinaryData("container keys are not strictly increasing")
+		}
+		if card == 0 || card > 1<<16 {
+			return errs.NewErrInvalidBinaryData("invalid container cardinality")
+		}
+
+		var c roaringContainer
+		if card <= arrayContainerMaxSize {
+			if len(data) < card*2 {
+				return errs.NewErrInvalidBinaryData("truncated array container")
+			}
+			ac := &arrayContainer{vals: make([]uint16, card)}
+			for j := range ac.vals {
+				ac.vals[j] = binary.LittleEndian.Uint16(data[j*2:])
+				if j > 0 && ac.vals[j] <= ac.vals[j-1] {
+					return errs.NewErrInvalidBinaryData("array container values are not strictly increasing")
+				}
+			}
+			data = data[card*2:]
+			c = ac
+		} else {
+			if len(data) < bitmapContainerWords*8 {
+				return errs.NewErrInvalidBinaryData("truncated bitmap container")
+			}
+			bc := &bitmapContainer{card: card}
+			cnt := 0
+			for j := range bc.words {
+				bc.words[j] = binary.LittleEndian.Uint64(data[j*8:])
+				cnt += bits.OnesCount64(bc.words[j])
+			}
+			if cnt != card {
+				return errs.NewErrInvalidBinaryData("bitmap container cardinality mismatch")
+			}
+			data = data[bitmapContainerWords*8:]
+			c = bc
+		}
+		res.keys = append(res.keys, key)
+		res.containers = append(res.containers, c)
+		res.size += card
+	}
+	if len(data) != 0 {
+		return errs.NewErrInvalidBinaryData("unexpected trailing bytes")
+	}
+	*Self = res
+	return nil
+}
+
+// NewRoaringBitmap 创建一个空的 RoaringBitmap
+func NewRoaringBitmap() *RoaringBitmap {
+	return &RoaringBitmap{}
+}
@@ -0,0 +1,219 @@
+// Package set
+/**
+* @Project : GenericGo
+* @File    : roaring_bitmap_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/10 15:40
+**/
+
+package set
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/iterator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoaringBitmap_AddRemove(t *testing.T) {
+	tests := []struct {
+		name       string
+		addVals    []uint32
+		removeVals []uint32
+		wantKeys   []uint32
+	}{
+		{
+			name:     "Empty bitmap",
+			wantKeys: []uint32{},
+		},
+		{
+			name:     "Values in different containers",
+			addVals:  []uint32{1 << 20, 5, 1<<16 + 1, 5, ^uint32(0)},
+			wantKeys: []uint32{5, 1<<16 + 1, 1 << 20, ^uint32(0)},
+		},
+		{
+			name:       "Remove empties container",
+			addVals:    []uint32{1, 1<<16 + 1},
+			removeVals: []uint32{1<<16 + 1, 2, 1 << 30},
+			wantKeys:   []uint32{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rb := NewRoaringBitmap()
+			rb.AddKeys(tt.addVals)
+			rb.RemoveKeys(tt.removeVals)
+			assert.Equal(t, tt.wantKeys, rb.Keys())
+			assert.Equal(t, tt.wantKeys, iterator.Collect(rb.Iterator()))
+			assert.Equal(t, len(tt.wantKeys), rb.Size())
+			assert.True(t, rb.ContainsAll(tt.wantKeys))
+			assert.False(t, rb.ContainsAny(tt.removeVals))
+			assert.Len(t, rb.keys, len(rb.containers))
+		})
+	}
+}
+
+func TestRoaringBitmap_ContainerConversion(t *testing.T) {
+	rb := NewRoaringBitmap()
+	for i := uint32(0); i < arrayContainerMaxSize; i++ {
+		rb.Add(i * 2)
+	}
+	assert.IsType(t, &arrayContainer{}, rb.containers[0])
+
+	// 超过 4096 个元素后转换为位图容器
+	rb.Add(1)
+	assert.IsType(t, &bitmapContainer{}, rb.containers[0])
+	assert.Equal(t, arrayContainerMaxSize+1, rb.Size())
+	assert.True(t, rb.Contains(1))
+	assert.True(t, rb.Contains(8190))
+	assert.False(t, rb.Contains(3))
+
+	// 元素减少到 4096 个后转换回数组容器
+	rb.Remove(1)
+	assert.IsType(t, &arrayContainer{}, rb.containers[0])
+	assert.Equal(t, arrayContainerMaxSize, rb.Size())
+	assert.False(t, rb.Contains(1))
+	assert.True(t, rb.Contains(8190))
+}
+
+func TestRoaringBitmap_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	rb := NewRoaringBitmap()
+	model := NewHashSet[uint32]()
+	for i := 0; i < 50000; i++ {
+		// 集中在少数几个容器中，覆盖两种容器之间的转换
+		key := uint32(r.Intn(4))<<16 | uint32(r.Intn(12000))
+		if r.Intn(3) == 0 {
+			rb.Remove(key)
+			model.Remove(key)
+		} else {
+			rb.Add(key)
+			model.Add(key)
+		}
+	}
+	want := model.Keys()
+	slices.Sort(want)
+	assert.Equal(t, want, rb.Keys())
+	assert.Equal(t, model.Size(), rb.Size())
+	assert.True(t, Equal[uint32](rb, model))
+
+	data, err := rb.MarshalBinary()
+	require.NoError(t, err)
+	decoded := NewRoaringBitmap()
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, want, decoded.Keys())
+	assert.Equal(t, rb.Size(), decoded.Size())
+}
+
+func TestRoaringBitmap_Binary(t *testing.T) {
+	rb := NewRoaringBitmap()
+	rb.AddKeys([]uint32{1, 2, 1 << 16})
+	data, err := rb.MarshalBinary()
+	require.NoError(t, err)
+	// 4字节容器数量 + 两个容器各6字节的头部 + 3个2字节的元素
+	assert.Len(t, data, 4+6*2+3*2)
+
+	decoded := NewRoaringBitmap()
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, []uint32{1, 2, 1 << 16}, decoded.Keys())
+
+	empty, err := NewRoaringBitmap().MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, decoded.UnmarshalBinary(empty))
+	assert.Equal(t, 0, decoded.Size())
+
+	// 不合法的数据会返回错误，并且不会修改原有的内容
+	header := func(count uint32) []byte {
+		return binary.LittleEndian.AppendUint32(nil, count)
+	}
+	container := func(key uint16, card uint32, vals ...uint16) []byte {
+		res := binary.LittleEndian.AppendUint16(nil, key)
+		res = binary.LittleEndian.AppendUint32(res, card)
+		for _, val := range vals {
+			res = binary.LittleEndian.AppendUint16(res, val)
+		}
+		return res
+	}
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{
+			name:    "Missing count",
+			data:    []byte{1},
+			wantErr: errs.NewErrInvalidBinaryData("missing container count"),
+		},
+		{
+			name:    "Count exceeds data",
+			data:    header(1000),
+			wantErr: errs.NewErrInvalidBinaryData("container count exceeds data length"),
+		},
+		{
+			name:    "Zero cardinality",
+			data:    slices.Concat(header(1), container(0, 0)),
+			wantErr: errs.NewErrInvalidBinaryData("invalid container cardinality"),
+		},
+		{
+			name:    "Truncated array",
+			data:    slices.Concat(header(1), container(0, 2, 1)),
+			wantErr: errs.NewErrInvalidBinaryData("truncated array container"),
+		},
+		{
+			name:    "Unsorted array",
+			data:    slices.Concat(header(1), container(0, 2, 2, 1)),
+			wantErr: errs.NewErrInvalidBinaryData("array container values are not strictly increasing"),
+		},
+		{
+			name:    "Unsorted keys",
+			data:    slices.Concat(header(2), container(1, 1, 1), container(0, 1, 1)),
+			wantErr: errs.NewErrInvalidBinaryData("container keys are not strictly increasing"),
+		},
+		{
+			name:    "Truncated bitmap",
+			data:    slices.Concat(header(1), container(0, arrayContainerMaxSize+1)),
+			wantErr: errs.NewErrInvalidBinaryData("truncated bitmap container"),
+		},
+		{
+			name:    "Trailing bytes",
+			data:    slices.Concat(header(1), container(0, 1, 1), []byte{0}),
+			wantErr: errs.NewErrInvalidBinaryData("unexpected trailing bytes"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rb := NewRoaringBitmap()
+			rb.Add(42)
+			err := rb.UnmarshalBinary(tt.data)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, []uint32{42}, rb.Keys())
+		})
+	}
+}
+
+func TestRoaringBitmap_BitmapBinary(t *testing.T) {
+	rb := NewRoaringBitmap()
+	for i := uint32(0); i < 10000; i++ {
+		rb.Add(1<<16 | i)
+	}
+	data, err := rb.MarshalBinary()
+	require.NoError(t, err)
+	assert.Len(t, data, 4+6+bitmapContainerWords*8)
+
+	decoded := NewRoaringBitmap()
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, rb.Keys(), decoded.Keys())
+	assert.IsType(t, &bitmapContainer{}, decoded.containers[0])
+
+	// 位图中1的数量与声明的元素数量不一致
+	binary.LittleEndian.PutUint32(data[6:], 9999)
+	assert.Equal(t, errs.NewErrInvalidBinaryData("bitmap container cardinality mismatch"), decoded.UnmarshalBinary(data))
+}
@@ -0,0 +1,91 @@
+// Package set
+/**
+* @Project : GenericGo
+* @File    : treeset.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/18 14:02
+**/
+
+package set
+
+import (
+	genericgo "github.com/HJH0924/GenericGo"
+	"github.com/HJH0924/GenericGo/tree"
+)
+
+var (
+	_ Set[any] = (*TreeSet[any])(nil)
+)
+
+// TreeSet 基于红黑树实现的有序集合
+// 与 HashSet 不同，Keys 总是按照 compare 从小到大的顺序返回
+type TreeSet[T comparable] struct {
+	tree *tree.RBTree[T, struct{}]
+}
+
+// Add 向 TreeSet 中添加一个元素
+func (Self *TreeSet[T]) Add(key T) {
+	Self.tree.Put(key, struct{}{})
+}
+
+// AddKeys 向 TreeSet 中添加一组元素
+func (Self *TreeSet[T]) AddKeys(keys []T) {
+	for _, key := range keys {
+		Self.Add(key)
+	}
+}
+
+// Remove 从 TreeSet 中删除一个元素
+func (Self *TreeSet[T]) Remove(key T) {
+	Self.tree.Delete(key)
+}
+
+// RemoveKeys 从 TreeSet 中删除一组元素
+func (Self *TreeSet[T]) RemoveKeys(keys []T) {
+	for _, key := range keys {
+		Self.Remove(key)
+	}
+}
+
+// Contains 检查 TreeSet 中是否包含某个元素
+func (Self *TreeSet[T]) Contains(key T) bool {
+	return Self.tree.Contains(key)
+}
+
+// ContainsAny 检查 TreeSet 中是否包含给定切片中的某个元素
+func (Self *TreeSet[T]) ContainsAny(keys []T) bool {
+	for _, key := range keys {
+		if Self.Contains(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsAll 检查 TreeSet 中是否包含给定切片中的所有元素
+func (Self *TreeSet[T]) ContainsAll(keys []T) bool {
+	for _, key := range keys {
+		if !Self.Contains(key) {
+			return false
+		}
+	}
+	return true
+}
+
+// Size 返回 TreeSet 中的元素数量
+func (Self *TreeSet[T]) Size() int {
+	return Self.tree.Size()
+}
+
+// Keys 按照从小到大的顺序返回集合中所有的元素
+func (Self *TreeSet[T]) Keys() []T {
+	return Self.tree.Keys()
+}
+
+// NewTreeSet 创建并返回一个新的TreeSet实例，compare 用于比较元素的大小
+func NewTreeSet[T comparable](compare genericgo.Comparator[T]) *TreeSet[T] {
+	return &TreeSet[T]{
+		tree: tree.NewRBTree[T, struct{}](compare),
+	}
+}
@@ -0,0 +1,137 @@
+// Package set
+/**
+* @Project : GenericGo
+* @File    : treeset_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/18 14:40
+**/
+
+package set
+
+import (
+	"cmp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeSet_AddKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		addVals  []int
+		wantKeys []int
+	}{
+		{
+			name:     "Test with unique values",
+			addVals:  []int{3, 1, 2},
+			wantKeys: []int{1, 2, 3},
+		},
+		{
+			name:     "Test with duplicate values",
+			addVals:  []int{3, 3, 1, 1, 2, 2},
+			wantKeys: []int{1, 2, 3},
+		},
+		{
+			name:     "Test with empty slice",
+			addVals:  []int{},
+			wantKeys: []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			treeSet := NewTreeSet[int](cmp.Compare[int])
+			treeSet.AddKeys(tt.addVals)
+			assert.Equal(t, tt.wantKeys, treeSet.Keys())
+			assert.Equal(t, len(tt.wantKeys), treeSet.Size())
+		})
+	}
+}
+
+func TestTreeSet_RemoveKeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		removeKeys []int
+		wantKeys   []int
+	}{
+		{
+			name:       "Test with unique values",
+			removeKeys: []int{1, 2, 3},
+			wantKeys:   []int{4, 5},
+		},
+		{
+			name:       "Test with duplicate values",
+			removeKeys: []int{1, 1, 2, 2, 3, 3},
+			wantKeys:   []int{4, 5},
+		},
+		{
+			name:       "Test with values not in set",
+			removeKeys: []int{6, 7},
+			wantKeys:   []int{1, 2, 3, 4, 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			treeSet := NewTreeSet[int](cmp.Compare[int])
+			treeSet.AddKeys([]int{5, 4, 3, 2, 1})
+			treeSet.RemoveKeys(tt.removeKeys)
+			assert.Equal(t, tt.wantKeys, treeSet.Keys())
+		})
+	}
+}
+
+func TestTreeSet_Contains(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         []int
+		target      []int
+		wantAny     bool
+		wantAll     bool
+		wantContain bool
+	}{
+		{
+			name:        "Source contains all target elements",
+			src:         []int{1, 2, 3, 4, 5},
+			target:      []int{1, 2, 3},
+			wantAny:     true,
+			wantAll:     true,
+			wantContain: true,
+		},
+		{
+			name:        "Source contains some target elements",
+			src:         []int{1, 2, 3, 4, 5},
+			target:      []int{1, 6},
+			wantAny:     true,
+			wantAll:     false,
+			wantContain: true,
+		},
+		{
+			name:        "Source contains no target elements",
+			src:         []int{1, 2, 3, 4, 5},
+			target:      []int{6, 7},
+			wantAny:     false,
+			wantAll:     false,
+			wantContain: false,
+		},
+		{
+			name:        "Source is empty",
+			src:         []int{},
+			target:      []int{6, 7},
+			wantAny:     false,
+			wantAll:     false,
+			wantContain: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			treeSet := NewTreeSet[int](cmp.Compare[int])
+			treeSet.AddKeys(tt.src)
+			assert.Equal(t, tt.wantAny, treeSet.ContainsAny(tt.target))
+			assert.Equal(t, tt.wantAll, treeSet.ContainsAll(tt.target))
+			assert.Equal(t, tt.wantContain, treeSet.Contains(tt.target[0]))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Package slice
+/**
+* @Project : GenericGo
+* @File    : topk.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/30 15:20
+**/
+
+package slice
+
+import (
+	genericgo "github.com/HJH0924/GenericGo"
+	"github.com/HJH0924/GenericGo/internal/heapx"
+)
+
+// TopK 返回 src 中按照 compare 最大的 k 个元素，结果从大到小排列，不会修改 src
+// 内部维护一个大小为 k 的小根堆，时间复杂度为 O(n log k)
+// 如果 k <= 0，返回空切片；如果 k 大于 src 的长度，返回所有元素
+func TopK[T any](src []T, k int, compare genericgo.Comparator[T]) []T {
+	if k <= 0 {
+		return []T{}
+	}
+	if k > len(src) {
+		k = len(src)
+	}
+
+	// 翻转比较函数得到小根堆，堆顶是目前选出的 k 个元素中最小的
+	reversed := func(l, r T) int {
+		return compare(r, l)
+	}
+	res := make([]T, k)
+	copy(res, src[:k])
+	heapx.Heapify(res, 2, reversed)
+	for _, val := range src[k:] {
+		if compare(val, res[0]) > 0 {
+			res[0] = val
+			heapx.Down(res, 0, 2, reversed)
+		}
+	}
+
+	// 堆排序：依次将堆顶（最小值）交换到末尾，最终得到从大到小的顺序
+	for n := k - 1; n > 0; n-- {
+		res[0], res[n] = res[n], res[0]
+		heapx.Down(res[:n], 0, 2, reversed)
+	}
+	return res
+}
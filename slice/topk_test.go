@@ -0,0 +1,97 @@
+// Package slice
+/**
+* @Project : GenericGo
+* @File    : topk_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/30 15:46
+**/
+
+package slice
+
+import (
+	"cmp"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopK(t *testing.T) {
+	testCases := []struct {
+		name    string
+		slice   []int
+		k       int
+		wantRes []int
+	}{
+		{
+			name:    "Empty slice",
+			slice:   []int{},
+			k:       3,
+			wantRes: []int{},
+		},
+		{
+			name:    "Zero k",
+			slice:   []int{1, 2, 3},
+			k:       0,
+			wantRes: []int{},
+		},
+		{
+			name:    "Negative k",
+			slice:   []int{1, 2, 3},
+			k:       -1,
+			wantRes: []int{},
+		},
+		{
+			name:    "K less than length",
+			slice:   []int{5, 1, 9, 3, 7, 2},
+			k:       3,
+			wantRes: []int{9, 7, 5},
+		},
+		{
+			name:    "K greater than length",
+			slice:   []int{2, 3, 1},
+			k:       5,
+			wantRes: []int{3, 2, 1},
+		},
+		{
+			name:    "Duplicate values",
+			slice:   []int{4, 4, 1, 4, 2},
+			k:       2,
+			wantRes: []int{4, 4},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			src := make([]int, len(tc.slice))
+			copy(src, tc.slice)
+			assert.Equal(t, tc.wantRes, TopK(src, tc.k, cmp.Compare[int]))
+			// TopK 不会修改原切片
+			assert.Equal(t, tc.slice, src)
+		})
+	}
+}
+
+func TestTopK_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(2024))
+	src := make([]int, 1000)
+	for i := range src {
+		src[i] = r.Intn(10000)
+	}
+	sorted := make([]int, len(src))
+	copy(sorted, src)
+	sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+
+	for _, k := range []int{1, 10, 100, 999, 1000} {
+		assert.Equal(t, sorted[:k], TopK(src, k, cmp.Compare[int]))
+	}
+	// 翻转比较函数得到最小的 k 个元素
+	smallest := TopK(src, 10, func(l, r int) int {
+		return cmp.Compare(r, l)
+	})
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, sorted[len(sorted)-1-i], smallest[i])
+	}
+}
@@ -0,0 +1,88 @@
+// Package stack
+/**
+* @Project : GenericGo
+* @File    : array_stack.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/2 09:52
+**/
+
+package stack
+
+import (
+	genericgo "github.com/HJH0924/GenericGo"
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/slice"
+)
+
+var (
+	_ Stack[any] = &ArrayStack[any]{}
+)
+
+// ArrayStack 是基于切片实现的栈，切片的末尾为栈顶，出栈时会通过 slice.ShrinkSlice 缩容。
+type ArrayStack[T any] struct {
+	vals []T
+}
+
+// Push 将元素压入栈顶。
+func (Self *ArrayStack[T]) Push(val T) {
+	Self.vals = append(Self.vals, val)
+}
+
+// Pop 移除并返回栈顶元素。如果栈为空，返回错误。
+func (Self *ArrayStack[T]) Pop() (T, error) {
+	if Self.IsEmpty() {
+		return genericgo.Zero[T](), errs.NewErrEmptyStack()
+	}
+	top := len(Self.vals) - 1
+	val := Self.vals[top]
+	// 释放对元素的引用，方便 GC 回收
+	Self.vals[top] = genericgo.Zero[T]()
+	Self.vals = slice.ShrinkSlice(Self.vals[:top])
+	return val, nil
+}
+
+// Peek 返回栈顶元素，但不移除它。如果栈为空，返回错误。
+func (Self *ArrayStack[T]) Peek() (T, error) {
+	if Self.IsEmpty() {
+		return genericgo.Zero[T](), errs.NewErrEmptyStack()
+	}
+	return Self.vals[len(Self.vals)-1], nil
+}
+
+// Len 返回栈中元素的数量。
+func (Self *ArrayStack[T]) Len() int {
+	return len(Self.vals)
+}
+
+// Cap 返回底层切片的容量。
+func (Self *ArrayStack[T]) Cap() int {
+	return cap(Self.vals)
+}
+
+// IsEmpty 返回栈是否为空。
+func (Self *ArrayStack[T]) IsEmpty() bool {
+	return len(Self.vals) == 0
+}
+
+// AsSlice 将栈转化为一个新切片，元素从栈底到栈顶排列。
+func (Self *ArrayStack[T]) AsSlice() []T {
+	res := make([]T, len(Self.vals))
+	copy(res, Self.vals)
+	return res
+}
+
+// NewArrayStack 创建一个初始容量为 capacity 的 ArrayStack。
+func NewArrayStack[T any](capacity int) *ArrayStack[T] {
+	return &ArrayStack[T]{
+		vals: make([]T, 0, capacity),
+	}
+}
+
+// NewArrayStackOf 创建一个 ArrayStack，vals 中的元素依次入栈，最后一个元素为栈顶。
+// vals 会被复制，之后对 vals 的修改不会影响栈。
+func NewArrayStackOf[T any](vals []T) *ArrayStack[T] {
+	res := NewArrayStack[T](len(vals))
+	res.vals = append(res.vals, vals...)
+	return res
+}
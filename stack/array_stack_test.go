@@ -0,0 +1,126 @@
+// Package stack
+/**
+* @Project : GenericGo
+* @File    : array_stack_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/2 14:05
+**/
+
+package stack
+
+import (
+	"testing"
+
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArrayStack_Push(t *testing.T) {
+	tests := []struct {
+		name      string
+		initVals  []int
+		pushVals  []int
+		wantSlice []int
+		wantTop   int
+	}{
+		{
+			name:      "Push to empty stack",
+			initVals:  []int{},
+			pushVals:  []int{1},
+			wantSlice: []int{1},
+			wantTop:   1,
+		},
+		{
+			name:      "Push to non-empty stack",
+			initVals:  []int{1, 2},
+			pushVals:  []int{3, 4},
+			wantSlice: []int{1, 2, 3, 4},
+			wantTop:   4,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := NewArrayStackOf(test.initVals)
+			for _, val := range test.pushVals {
+				s.Push(val)
+			}
+			assert.Equal(t, test.wantSlice, s.AsSlice())
+			assert.Equal(t, len(test.wantSlice), s.Len())
+			top, err := s.Peek()
+			require.NoError(t, err)
+			assert.Equal(t, test.wantTop, top)
+		})
+	}
+}
+
+func TestArrayStack_Pop(t *testing.T) {
+	tests := []struct {
+		name     string
+		initVals []int
+		wantVals []int
+	}{
+		{
+			name:     "Pop from empty stack",
+			initVals: []int{},
+			wantVals: []int{},
+		},
+		{
+			name:     "Pop from non-empty stack",
+			initVals: []int{1, 2, 3},
+			wantVals: []int{3, 2, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := NewArrayStackOf(test.initVals)
+			res := make([]int, 0, len(test.initVals))
+			for !s.IsEmpty() {
+				val, err := s.Pop()
+				require.NoError(t, err)
+				res = append(res, val)
+			}
+			assert.Equal(t, test.wantVals, res)
+			assert.Equal(t, []int{}, s.AsSlice())
+
+			_, err := s.Pop()
+			assert.Equal(t, errs.NewErrEmptyStack(), err)
+			_, err = s.Peek()
+			assert.Equal(t, errs.NewErrEmptyStack(), err)
+		})
+	}
+}
+
+func TestArrayStack_Shrink(t *testing.T) {
+	s := NewArrayStack[int](0)
+	for i := 0; i < 4096; i++ {
+		s.Push(i)
+	}
+	grownCap := s.Cap()
+	for i := 4095; i >= 100; i-- {
+		val, err := s.Pop()
+		require.NoError(t, err)
+		require.Equal(t, i, val)
+	}
+	// 出栈后底层切片应当缩容，剩余元素不变
+	assert.Less(t, s.Cap(), grownCap)
+	assert.Equal(t, 100, s.Len())
+	for i := 99; i >= 0; i-- {
+		val, err := s.Pop()
+		require.NoError(t, err)
+		require.Equal(t, i, val)
+	}
+}
+
+func TestNewArrayStackOf(t *testing.T) {
+	vals := []int{1, 2, 3}
+	s := NewArrayStackOf(vals)
+	// 修改原切片不会影响栈
+	vals[2] = 100
+	top, err := s.Peek()
+	require.NoError(t, err)
+	assert.Equal(t, 3, top)
+}
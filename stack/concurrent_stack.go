@@ -0,0 +1,72 @@
+// Package stack
+/**
+* @Project : GenericGo
+* @File    : concurrent_stack.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/2 11:15
+**/
+
+package stack
+
+import "sync"
+
+var (
+	_ Stack[any] = &ConcurrentStack[any]{}
+)
+
+// ConcurrentStack 是一个线程安全的 Stack 接口包装器，它嵌入了泛型 Stack 接口，
+// 并提供了读写锁来确保并发访问时的数据安全。
+type ConcurrentStack[T any] struct {
+	Stack[T]
+	rwLock sync.RWMutex
+}
+
+// Push 将元素压入栈顶。
+func (cs *ConcurrentStack[T]) Push(val T) {
+	cs.rwLock.Lock() // 写锁，确保独占访问
+	defer cs.rwLock.Unlock()
+	cs.Stack.Push(val)
+}
+
+// Pop 移除并返回栈顶元素。如果栈为空，返回错误。
+func (cs *ConcurrentStack[T]) Pop() (T, error) {
+	cs.rwLock.Lock() // 写锁，确保独占访问
+	defer cs.rwLock.Unlock()
+	return cs.Stack.Pop()
+}
+
+// Peek 返回栈顶元素，但不移除它。如果栈为空，返回错误。
+func (cs *ConcurrentStack[T]) Peek() (T, error) {
+	cs.rwLock.RLock() // 读锁，允许多个读操作
+	defer cs.rwLock.RUnlock()
+	return cs.Stack.Peek()
+}
+
+// Len 返回栈中元素的数量。
+func (cs *ConcurrentStack[T]) Len() int {
+	cs.rwLock.RLock() // 读锁，允许多个读操作
+	defer cs.rwLock.RUnlock()
+	return cs.Stack.Len()
+}
+
+// IsEmpty 返回栈是否为空。
+func (cs *ConcurrentStack[T]) IsEmpty() bool {
+	cs.rwLock.RLock() // 读锁，允许多个读操作
+	defer cs.rwLock.RUnlock()
+	return cs.Stack.IsEmpty()
+}
+
+// AsSlice 将栈转化为一个新切片，元素从栈底到栈顶排列。
+func (cs *ConcurrentStack[T]) AsSlice() []T {
+	cs.rwLock.RLock() // 读锁，允许多个读操作
+	defer cs.rwLock.RUnlock()
+	return cs.Stack.AsSlice()
+}
+
+// NewConcurrentStackOf 创建并返回一个新的线程安全的 ConcurrentStack 实例。
+func NewConcurrentStackOf[T any](stack Stack[T]) *ConcurrentStack[T] {
+	return &ConcurrentStack[T]{
+		Stack: stack,
+	}
+}
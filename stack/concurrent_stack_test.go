@@ -0,0 +1,88 @@
+// Package stack
+/**
+* @Project : GenericGo
+* @File    : concurrent_stack_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/2 15:20
+**/
+
+package stack
+
+import (
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentStack(t *testing.T) {
+	const (
+		goroutines = 8
+		perWorker  = 1000
+	)
+	tests := []struct {
+		name  string
+		stack Stack[int]
+	}{
+		{
+			name:  "Array stack",
+			stack: NewArrayStack[int](0),
+		},
+		{
+			name:  "Linked stack",
+			stack: NewLinkedStack[int](),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := NewConcurrentStackOf(test.stack)
+			var wg sync.WaitGroup
+			for g := 0; g < goroutines; g++ {
+				wg.Add(1)
+				go func(g int) {
+					defer wg.Done()
+					for i := 0; i < perWorker; i++ {
+						s.Push(g*perWorker + i)
+						_, _ = s.Peek()
+						_ = s.Len()
+					}
+				}(g)
+			}
+			wg.Wait()
+			assert.Equal(t, goroutines*perWorker, s.Len())
+
+			var (
+				mutex sync.Mutex
+				got   = make([]int, 0, goroutines*perWorker)
+			)
+			for g := 0; g < goroutines; g++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := 0; i < perWorker; i++ {
+						val, err := s.Pop()
+						if !assert.NoError(t, err) {
+							return
+						}
+						mutex.Lock()
+						got = append(got, val)
+						mutex.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+
+			assert.True(t, s.IsEmpty())
+			_, err := s.Pop()
+			assert.Equal(t, errs.NewErrEmptyStack(), err)
+			sort.Ints(got)
+			for i, val := range got {
+				assert.Equal(t, i, val)
+			}
+		})
+	}
+}
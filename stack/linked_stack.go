@@ -0,0 +1,96 @@
+// Package stack
+/**
+* @Project : GenericGo
+* @File    : linked_stack.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/2 10:36
+**/
+
+package stack
+
+import (
+	genericgo "github.com/HJH0924/GenericGo"
+	"github.com/HJH0924/GenericGo/errs"
+)
+
+var (
+	_ Stack[any] = &LinkedStack[any]{}
+)
+
+// linkedStackNode 是 LinkedStack 的节点，next 指向下一个更靠近栈底的节点
+type linkedStackNode[T any] struct {
+	val  T
+	next *linkedStackNode[T]
+}
+
+// LinkedStack 是基于单向链表实现的栈，链表头为栈顶，入栈和出栈都不需要扩缩容。
+type LinkedStack[T any] struct {
+	top    *linkedStackNode[T]
+	length int
+}
+
+// Push 将元素压入栈顶。
+func (Self *LinkedStack[T]) Push(val T) {
+	Self.top = &linkedStackNode[T]{
+		val:  val,
+		next: Self.top,
+	}
+	Self.length++
+}
+
+// Pop 移除并返回栈顶元素。如果栈为空，返回错误。
+func (Self *LinkedStack[T]) Pop() (T, error) {
+	if Self.IsEmpty() {
+		return genericgo.Zero[T](), errs.NewErrEmptyStack()
+	}
+	node := Self.top
+	Self.top = node.next
+	node.next = nil
+	Self.length--
+	return node.val, nil
+}
+
+// Peek 返回栈顶元素，但不移除它。如果栈为空，返回错误。
+func (Self *LinkedStack[T]) Peek() (T, error) {
+	if Self.IsEmpty() {
+		return genericgo.Zero[T](), errs.NewErrEmptyStack()
+	}
+	return Self.top.val, nil
+}
+
+// Len 返回栈中元素的数量。
+func (Self *LinkedStack[T]) Len() int {
+	return Self.length
+}
+
+// IsEmpty 返回栈是否为空。
+func (Self *LinkedStack[T]) IsEmpty() bool {
+	return Self.top == nil
+}
+
+// AsSlice 将栈转化为一个新切片，元素从栈底到栈顶排列。
+func (Self *LinkedStack[T]) AsSlice() []T {
+	res := make([]T, Self.length)
+	// 链表从栈顶开始，从切片末尾往前填充
+	i := Self.length - 1
+	for p := Self.top; p != nil; p = p.next {
+		res[i] = p.val
+		i--
+	}
+	return res
+}
+
+// NewLinkedStack 创建一个空的 LinkedStack。
+func NewLinkedStack[T any]() *LinkedStack[T] {
+	return &LinkedStack[T]{}
+}
+
+// NewLinkedStackOf 创建一个 LinkedStack，vals 中的元素依次入栈，最后一个元素为栈顶。
+func NewLinkedStackOf[T any](vals []T) *LinkedStack[T] {
+	res := NewLinkedStack[T]()
+	for _, val := range vals {
+		res.Push(val)
+	}
+	return res
+}
@@ -0,0 +1,95 @@
+// Package stack
+/**
+* @Project : GenericGo
+* @File    : linked_stack_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/2 14:40
+**/
+
+package stack
+
+import (
+	"testing"
+
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkedStack_Push(t *testing.T) {
+	tests := []struct {
+		name      string
+		initVals  []int
+		pushVals  []int
+		wantSlice []int
+		wantTop   int
+	}{
+		{
+			name:      "Push to empty stack",
+			initVals:  []int{},
+			pushVals:  []int{1},
+			wantSlice: []int{1},
+			wantTop:   1,
+		},
+		{
+			name:      "Push to non-empty stack",
+			initVals:  []int{1, 2},
+			pushVals:  []int{3, 4},
+			wantSlice: []int{1, 2, 3, 4},
+			wantTop:   4,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := NewLinkedStackOf(test.initVals)
+			for _, val := range test.pushVals {
+				s.Push(val)
+			}
+			assert.Equal(t, test.wantSlice, s.AsSlice())
+			assert.Equal(t, len(test.wantSlice), s.Len())
+			top, err := s.Peek()
+			require.NoError(t, err)
+			assert.Equal(t, test.wantTop, top)
+		})
+	}
+}
+
+func TestLinkedStack_Pop(t *testing.T) {
+	tests := []struct {
+		name     string
+		initVals []int
+		wantVals []int
+	}{
+		{
+			name:     "Pop from empty stack",
+			initVals: []int{},
+			wantVals: []int{},
+		},
+		{
+			name:     "Pop from non-empty stack",
+			initVals: []int{1, 2, 3},
+			wantVals: []int{3, 2, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := NewLinkedStackOf(test.initVals)
+			res := make([]int, 0, len(test.initVals))
+			for !s.IsEmpty() {
+				val, err := s.Pop()
+				require.NoError(t, err)
+				res = append(res, val)
+			}
+			assert.Equal(t, test.wantVals, res)
+			assert.Equal(t, []int{}, s.AsSlice())
+
+			_, err := s.Pop()
+			assert.Equal(t, errs.NewErrEmptyStack(), err)
+			_, err = s.Peek()
+			assert.Equal(t, errs.NewErrEmptyStack(), err)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Package stack
+/**
+* @Project : GenericGo
+* @File    : types.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/12/2 09:30
+**/
+
+package stack
+
+type Stack[T any] interface {
+	// Push 将元素压入栈顶。
+	Push(val T)
+
+	// Pop 移除并返回栈顶元素。
+	// 如果栈为空，返回 errs.NewErrEmptyStack。
+	// 可能会发生缩容。
+	Pop() (T, error)
+
+	// Peek 返回栈顶元素，但不移除它。
+	// 如果栈为空，返回 errs.NewErrEmptyStack。
+	Peek() (T, error)
+
+	// Len 返回栈中元素的数量。
+	Len() int
+
+	// IsEmpty 返回栈是否为空。
+	IsEmpty() bool
+
+	// AsSlice 将栈转化为一个新切片，元素从栈底到栈顶排列，
+	// 即使栈为空，也返回一个长度为0的切片。
+	AsSlice() []T
+}
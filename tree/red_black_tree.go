@@ -9,7 +9,16 @@
 
 package tree
 
-import genericgo "github.com/HJH0924/GenericGo"
+import (
+	genericgo "github.com/HJH0924/GenericGo"
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/iterator"
+	"github.com/HJH0924/GenericGo/tuple"
+)
+
+var (
+	_ iterator.ReverseIterable[tuple.Pair[any, any]] = &RBTree[any, any]{}
+)
 
 // RBTree 定义了红黑树的结构
 type RBTree[K any, V any] struct {
@@ -18,6 +27,7 @@ type RBTree[K any, V any] struct {
 	size    int                     // 树中节点的数量
 }
 
+// NewRBTree 创建一个空的红黑树，compare 用于比较键的大小。
 func NewRBTree[K any, V any](compare genericgo.Comparator[K]) *RBTree[K, V] {
 	return &RBTree[K, V]{
 		root:    nil,
@@ -26,9 +36,249 @@ func NewRBTree[K any, V any](compare genericgo.Comparator[K]) *RBTree[K, V] {
 	}
 }
 
+// Size 返回红黑树中节点的数量。
 func (Self *RBTree[K, V]) Size() int {
 	if Self.root == nil {
 		return 0
 	}
 	return Self.size
 }
+
+// Put 向红黑树中插入一个键值对，如果键已经存在，则更新其对应的值。
+func (Self *RBTree[K, V]) Put(key K, val V) {
+	var parent *rbNode[K, V]
+	cmp := 0
+	for cur := Self.root; cur != nil; {
+		parent = cur
+		cmp = Self.compare(key, cur.key)
+		switch {
+		case cmp < 0:
+			cur = cur.left
+		case cmp > 0:
+			cur = cur.right
+		default:
+			cur.val = val
+			return
+		}
+	}
+
+	node := newRBNode(key, val)
+	node.parent = parent
+	switch {
+	case parent == nil:
+		Self.root = node
+	case cmp < 0:
+		parent.left = node
+	default:
+		parent.right = node
+	}
+	Self.size++
+	addSizeToAncestors(node, 1)
+	Self.fixAfterPut(node)
+}
+
+// Get 返回键对应的值，第二个返回值表示键是否存在。
+func (Self *RBTree[K, V]) Get(key K) (V, bool) {
+	node := Self.findNode(key)
+	if node == nil {
+		return genericgo.Zero[V](), false
+	}
+	return node.val, true
+}
+
+// Delete 删除键对应的节点，并返回被删除的值，第二个返回值表示键是否存在。
+func (Self *RBTree[K, V]) Delete(key K) (V, bool) {
+	node := Self.findNode(key)
+	if node == nil {
+		return genericgo.Zero[V](), false
+	}
+	val := node.val
+	Self.deleteNode(node)
+	return val, true
+}
+
+// Min 返回最小的键及其对应的值，树为空时第三个返回值为 false。
+func (Self *RBTree[K, V]) Min() (K, V, bool) {
+	return entryOf(minimum(Self.root))
+}
+
+// Max 返回最大的键及其对应的值，树为空时第三个返回值为 false。
+func (Self *RBTree[K, V]) Max() (K, V, bool) {
+	return entryOf(maximum(Self.root))
+}
+
+// Contains 判断红黑树中是否包含指定的键。
+func (Self *RBTree[K, V]) Contains(key K) bool {
+	return Self.findNode(key) != nil
+}
+
+// Keys 按照从小到大的顺序返回所有的键，即使树为空，也返回一个长度为0的切片。
+func (Self *RBTree[K, V]) Keys() []K {
+	res := make([]K, 0, Self.size)
+	for node := minimum(Self.root); node != nil; node = successor(node) {
+		res = append(res, node.key)
+	}
+	return res
+}
+
+// Values 按照键从小到大的顺序返回所有的值，即使树为空，也返回一个长度为0的切片。
+func (Self *RBTree[K, V]) Values() []V {
+	res := make([]V, 0, Self.size)
+	for node := minimum(Self.root); node != nil; node = successor(node) {
+		res = append(res, node.val)
+	}
+	return res
+}
+
+// Floor 返回小于等于 key 的最大键及其对应的值，不存在时第三个返回值为 false。
+func (Self *RBTree[K, V]) Floor(key K) (K, V, bool) {
+	return entryOf(Self.floorNode(key, true))
+}
+
+// Ceiling 返回大于等于 key 的最小键及其对应的值，不存在时第三个返回值为 false。
+func (Self *RBTree[K, V]) Ceiling(key K) (K, V, bool) {
+	return entryOf(Self.ceilingNode(key, true))
+}
+
+// Lower 返回严格小于 key 的最大键及其对应的值，不存在时第三个返回值为 false。
+func (Self *RBTree[K, V]) Lower(key K) (K, V, bool) {
+	return entryOf(Self.floorNode(key, false))
+}
+
+// Higher 返回严格大于 key 的最小键及其对应的值，不存在时第三个返回值为 false。
+func (Self *RBTree[K, V]) Higher(key K) (K, V, bool) {
+	return entryOf(Self.ceilingNode(key, false))
+}
+
+// Ascend 按照键从小到大的顺序遍历所有的键值对，visit 返回 false 时停止遍历。
+func (Self *RBTree[K, V]) Ascend(visit func(key K, val V) bool) {
+	for node := minimum(Self.root); node != nil; node = successor(node) {
+		if !visit(node.key, node.val) {
+			return
+		}
+	}
+}
+
+// Descend 按照键从大到小的顺序遍历所有的键值对，visit 返回 false 时停止遍历。
+func (Self *RBTree[K, V]) Descend(visit func(key K, val V) bool) {
+	for node := maximum(Self.root); node != nil; node = predecessor(node) {
+		if !visit(node.key, node.val) {
+			return
+		}
+	}
+}
+
+// Iterator 返回按照键从小到大的顺序遍历所有键值对的迭代器。
+func (Self *RBTree[K, V]) Iterator() iterator.Iterator[tuple.Pair[K, V]] {
+	return nodeIterator(minimum(Self.root), successor[K, V])
+}
+
+// ReverseIterator 返回按照键从大到小的顺序遍历所有键值对的迭代器。
+func (Self *RBTree[K, V]) ReverseIterator() iterator.Iterator[tuple.Pair[K, V]] {
+	return nodeIterator(maximum(Self.root), predecessor[K, V])
+}
+
+// Range 按照键从小到大的顺序遍历 from 和 to 之间的键值对，visit 返回 false 时停止遍历。
+// fromInclusive 和 toInclusive 分别表示是否包含 from 和 to 这两个端点。
+// 如果 from 大于 to，则不会访问任何键值对。
+func (Self *RBTree[K, V]) Range(from K, fromInclusive bool, to K, toInclusive bool, visit func(key K, val V) bool) {
+	for node := Self.ceilingNode(from, fromInclusive); node != nil; node = successor(node) {
+		cmp := Self.compare(node.key, to)
+		if cmp > 0 || (cmp == 0 && !toInclusive) {
+			return
+		}
+		if !visit(node.key, node.val) {
+			return
+		}
+	}
+}
+
+// DescendRange 按照键从大到小的顺序遍历 from 和 to 之间的键值对，visit 返回 false 时停止遍历。
+// 这里 from 是上界，to 是下界，fromInclusive 和 toInclusive 分别表示是否包含 from 和 to 这两个端点。
+// 如果 from 小于 to，则不会访问任何键值对。
+func (Self *RBTree[K, V]) DescendRange(from K, fromInclusive bool, to K, toInclusive bool, visit func(key K, val V) bool) {
+	for node := Self.floorNode(from, fromInclusive); node != nil; node = predecessor(node) {
+		cmp := Self.compare(node.key, to)
+		if cmp < 0 || (cmp == 0 && !toInclusive) {
+			return
+		}
+		if !visit(node.key, node.val) {
+			return
+		}
+	}
+}
+
+// Rank 返回严格小于 key 的键的数量，即 key 在有序键中的排名（从0开始），时间复杂度为 O(log n)。
+// key 不必存在于树中。
+func (Self *RBTree[K, V]) Rank(key K) int {
+	res := 0
+	for cur := Self.root; cur != nil; {
+		if Self.compare(key, cur.key) <= 0 {
+			cur = cur.left
+		} else {
+			// cur 及其左子树中的键都小于 key
+			res += sizeOf(cur.left) + 1
+			cur = cur.right
+		}
+	}
+	return res
+}
+
+// Select 返回第 k 小（从0开始）的键及其对应的值，时间复杂度为 O(log n)。
+// 如果 k 超出合法范围，返回错误。
+func (Self *RBTree[K, V]) Select(k int) (K, V, error) {
+	if k < 0 || k >= Self.size {
+		return genericgo.Zero[K](), genericgo.Zero[V](), errs.NewErrIndexOutOfRange(Self.size, k)
+	}
+	cur := Self.root
+	for {
+		leftSize := sizeOf(cur.left)
+		switch {
+		case k < leftSize:
+			cur = cur.left
+		case k > leftSize:
+			k -= leftSize + 1
+			cur = cur.right
+		default:
+			return cur.key, cur.val, nil
+		}
+	}
+}
+
+// findNode 查找键对应的节点，不存在时返回 nil
+func (Self *RBTree[K, V]) findNode(key K) *rbNode[K, V] {
+	cur := Self.root
+	for cur != nil {
+		cmp := Self.compare(key, cur.key)
+		switch {
+		case cmp < 0:
+			cur = cur.left
+		case cmp > 0:
+			cur = cur.right
+		default:
+			return cur
+		}
+	}
+	return nil
+}
+
+// entryOf 返回节点的键和值，节点为 nil 时第三个返回值为 false
+func entryOf[K any, V any](node *rbNode[K, V]) (K, V, bool) {
+	if node == nil {
+		return genericgo.Zero[K](), genericgo.Zero[V](), false
+	}
+	return node.key, node.val, true
+}
+
+// nodeIterator 从 start 开始，使用 next 依次访问节点的迭代器
+func nodeIterator[K any, V any](start *rbNode[K, V], next func(*rbNode[K, V]) *rbNode[K, V]) iterator.Iterator[tuple.Pair[K, V]] {
+	node := start
+	return iterator.FromFunc(func() (tuple.Pair[K, V], bool) {
+		if node == nil {
+			return tuple.Pair[K, V]{}, false
+		}
+		res := tuple.NewPair(node.key, node.val)
+		node = next(node)
+		return res, true
+	})
+}
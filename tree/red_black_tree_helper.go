@@ -9,6 +9,11 @@
 
 package tree
 
+import (
+	"errors"
+	"fmt"
+)
+
 // nodeColor 定义了红黑树节点的颜色类型，使用布尔值表示。
 type nodeColor bool
 
@@ -25,6 +30,8 @@ type rbNode[K any, V any] struct {
 	left   *rbNode[K, V]
 	right  *rbNode[K, V]
 	parent *rbNode[K, V]
+	// size 是以该节点为根的子树中节点的数量，用于支持 Rank 和 Select 等顺序统计操作
+	size int
 }
 
 func newRBNode[K any, V any](key K, val V) *rbNode[K, V] {
@@ -35,5 +42,431 @@ func newRBNode[K any, V any](key K, val V) *rbNode[K, V] {
 		left:   nil,
 		right:  nil,
 		parent: nil,
+		size:   1,
+	}
+}
+
+// 以下辅助函数都可以接受 nil 节点，nil 节点视为黑色的叶子节点，
+// 这样在插入和删除的修复过程中就不需要对 nil 做额外的判断。
+
+// colorOf 返回节点的颜色，nil 节点为黑色
+func colorOf[K any, V any](n *rbNode[K, V]) nodeColor {
+	if n == nil {
+		return Black
+	}
+	return n.color
+}
+
+// setColor 设置节点的颜色，对 nil 节点不做任何操作
+func setColor[K any, V any](n *rbNode[K, V], color nodeColor) {
+	if n != nil {
+		n.color = color
+	}
+}
+
+// parentOf 返回节点的父节点
+func parentOf[K any, V any](n *rbNode[K, V]) *rbNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	return n.parent
+}
+
+// leftOf 返回节点的左孩子
+func leftOf[K any, V any](n *rbNode[K, V]) *rbNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	return n.left
+}
+
+// rightOf 返回节点的右孩子
+func rightOf[K any, V any](n *rbNode[K, V]) *rbNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	return n.right
+}
+
+// sizeOf 返回以 n 为根的子树中节点的数量，nil 节点为0
+func sizeOf[K any, V any](n *rbNode[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// updateSize 根据左右孩子重新计算 n 的子树大小
+func updateSize[K any, V any](n *rbNode[K, V]) {
+	n.size = sizeOf(n.left) + sizeOf(n.right) + 1
+}
+
+// addSizeToAncestors 将 n 的所有祖先节点的子树大小加上 delta
+func addSizeToAncestors[K any, V any](n *rbNode[K, V], delta int) {
+	for p := n.parent; p != nil; p = p.parent {
+		p.size += delta
+	}
+}
+
+// minimum 返回以 n 为根的子树中键最小的节点
+func minimum[K any, V any](n *rbNode[K, V]) *rbNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+// maximum 返回以 n 为根的子树中键最大的节点
+func maximum[K any, V any](n *rbNode[K, V]) *rbNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+// successor 返回中序遍历中 n 的后继节点，不存在时返回 nil
+func successor[K any, V any](n *rbNode[K, V]) *rbNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	if n.right != nil {
+		return minimum(n.right)
+	}
+	p := n.parent
+	for p != nil && n == p.right {
+		n, p = p, p.parent
+	}
+	return p
+}
+
+// predecessor 返回中序遍历中 n 的前驱节点，不存在时返回 nil
+func predecessor[K any, V any](n *rbNode[K, V]) *rbNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	if n.left != nil {
+		return maximum(n.left)
+	}
+	p := n.parent
+	for p != nil && n == p.left {
+		n, p = p, p.parent
+	}
+	return p
+}
+
+// ceilingNode 返回键大于等于 key 的最小节点，inclusive 为 false 时返回键严格大于 key 的最小节点
+func (Self *RBTree[K, V]) ceilingNode(key K, inclusive bool) *rbNode[K, V] {
+	var res *rbNode[K, V]
+	for cur := Self.root; cur != nil; {
+		cmp := Self.compare(key, cur.key)
+		if cmp < 0 || (cmp == 0 && inclusive) {
+			// cur 满足条件，继续在左子树中寻找更小的
+			res = cur
+			cur = cur.left
+		} else {
+			cur = cur.right
+		}
+	}
+	return res
+}
+
+// floorNode 返回键小于等于 key 的最大节点，inclusive 为 false 时返回键严格小于 key 的最大节点
+func (Self *RBTree[K, V]) floorNode(key K, inclusive bool) *rbNode[K, V] {
+	var res *rbNode[K, V]
+	for cur := Self.root; cur != nil; {
+		cmp := Self.compare(key, cur.key)
+		if cmp > 0 || (cmp == 0 && inclusive) {
+			// cur 满足条件，继续在右子树中寻找更大的
+			res = cur
+			cur = cur.right
+		} else {
+			cur = cur.left
+		}
+	}
+	return res
+}
+
+// rotateLeft 以 x 为支点左旋
+//
+//	  x                y
+//	 / \              / \
+//	a   y    ==>     x   c
+//	   / \          / \
+//	  b   c        a   b
+func (Self *RBTree[K, V]) rotateLeft(x *rbNode[K, V]) {
+	if x == nil || x.right == nil {
+		return
+	}
+	y := x.right
+	x.right = y.left
+	if y.left != nil {
+		y.left.parent = x
+	}
+	y.parent = x.parent
+	Self.replaceChild(x.parent, x, y)
+	y.left = x
+	x.parent = y
+	// y 成为原来 x 的位置，子树大小与原来的 x 相同
+	y.size = x.size
+	updateSize(x)
+}
+
+// rotateRight 以 y 为支点右旋
+//
+//	    y            x
+//	   / \          / \
+//	  x   c  ==>   a   y
+//	 / \              / \
+//	a   b            b   c
+func (Self *RBTree[K, V]) rotateRight(y *rbNode[K, V]) {
+	if y == nil || y.left == nil {
+		return
+	}
+	x := y.left
+	y.left = x.right
+	if x.right != nil {
+		x.right.parent = y
+	}
+	x.parent = y.parent
+	Self.replaceChild(y.parent, y, x)
+	x.right = y
+	y.parent = x
+	x.size = y.size
+	updateSize(y)
+}
+
+// replaceChild 将 parent 指向 oldChild 的指针改为指向 newChild，parent 为 nil 时更新根节点
+func (Self *RBTree[K, V]) replaceChild(parent, oldChild, newChild *rbNode[K, V]) {
+	switch {
+	case parent == nil:
+		Self.root = newChild
+	case parent.left == oldChild:
+		parent.left = newChild
+	default:
+		parent.right = newChild
+	}
+}
+
+// fixAfterPut 插入红色节点 x 之后，通过变色和旋转恢复红黑树的性质
+func (Self *RBTree[K, V]) fixAfterPut(x *rbNode[K, V]) {
+	for x != nil && x != Self.root && colorOf(parentOf(x)) == Red {
+		parent, grandparent := parentOf(x), parentOf(parentOf(x))
+		if parent == leftOf(grandparent) {
+			uncle := rightOf(grandparent)
+			if colorOf(uncle) == Red {
+				// 叔叔节点为红色：父亲和叔叔变黑，祖父变红，继续向上修复
+				setColor(parent, Black)
+				setColor(uncle, Black)
+				setColor(grandparent, Red)
+				x = grandparent
+				continue
+			}
+			if x == rightOf(parent) {
+				// LR 型，先左旋转换成 LL 型
+				x = parent
+				Self.rotateLeft(x)
+			}
+			// LL 型：父亲变黑，祖父变红，祖父右旋
+			setColor(parentOf(x), Black)
+			setColor(parentOf(parentOf(x)), Red)
+			Self.rotateRight(parentOf(parentOf(x)))
+		} else {
+			uncle := leftOf(grandparent)
+			if colorOf(uncle) == Red {
+				setColor(parent, Black)
+				setColor(uncle, Black)
+				setColor(grandparent, Red)
+				x = grandparent
+				continue
+			}
+			if x == leftOf(parent) {
+				// RL 型，先右旋转换成 RR 型
+				x = parent
+				Self.rotateRight(x)
+			}
+			// RR 型：父亲变黑，祖父变红，祖父左旋
+			setColor(parentOf(x), Black)
+			setColor(parentOf(parentOf(x)), Red)
+			Self.rotateLeft(parentOf(parentOf(x)))
+		}
+	}
+	Self.root.color = Black
+}
+
+// deleteNode 从树中删除节点 p 并恢复红黑树的性质
+func (Self *RBTree[K, V]) deleteNode(p *rbNode[K, V]) {
+	Self.size--
+
+	// p 有两个孩子时，用后继节点的键值替换 p，转换为删除后继节点，后继节点最多只有一个右孩子
+	if p.left != nil && p.right != nil {
+		s := successor(p)
+		p.key, p.val = s.key, s.val
+		p = s
+	}
+
+	// 此时 p 最多只有一个孩子，先将其从祖先节点的子树大小中扣除
+	addSizeToAncestors(p, -1)
+
+	replacement := p.left
+	if replacement == nil {
+		replacement = p.right
+	}
+
+	if replacement != nil {
+		// p 只有一个孩子，用孩子替换 p
+		replacement.parent = p.parent
+		Self.replaceChild(p.parent, p, replacement)
+		p.left, p.right, p.parent = nil, nil, nil
+		if p.color == Black {
+			Self.fixAfterDelete(replacement)
+		}
+		return
+	}
+
+	if p.parent == nil {
+		// p 是唯一的节点
+		Self.root = nil
+		return
+	}
+
+	// p 是叶子节点，先把 p 当作"虚拟"的替换节点进行修复，再将其摘除
+	// 修复过程中的旋转会根据孩子重新计算子树大小，所以将 p 的子树大小置为0
+	p.size = 0
+	if p.color == Black {
+		Self.fixAfterDelete(p)
+	}
+	if p.parent != nil {
+		Self.replaceChild(p.parent, p, nil)
+		p.parent = nil
+	}
+}
+
+// fixAfterDelete 删除黑色节点后，x 所在的路径少了一个黑色节点，通过变色和旋转恢复红黑树的性质
+func (Self *RBTree[K, V]) fixAfterDelete(x *rbNode[K, V]) {
+	for x != Self.root && colorOf(x) == Black {
+		if x == leftOf(parentOf(x)) {
+			sibling := rightOf(parentOf(x))
+			if colorOf(sibling) == Red {
+				// 兄弟为红色：转换为兄弟为黑色的情况
+				setColor(sibling, Black)
+				setColor(parentOf(x), Red)
+				Self.rotateLeft(parentOf(x))
+				sibling = rightOf(parentOf(x))
+			}
+			if colorOf(leftOf(sibling)) == Black && colorOf(rightOf(sibling)) == Black {
+				// 兄弟的孩子都是黑色：兄弟变红，问题上移到父亲
+				setColor(sibling, Red)
+				x = parentOf(x)
+				continue
+			}
+			if colorOf(rightOf(sibling)) == Black {
+				// 兄弟的左孩子为红色：转换为兄弟右孩子为红色的情况
+				setColor(leftOf(sibling), Black)
+				setColor(sibling, Red)
+				Self.rotateRight(sibling)
+				sibling = rightOf(parentOf(x))
+			}
+			// 兄弟的右孩子为红色：旋转后借一个黑色节点过来，修复结束
+			setColor(sibling, colorOf(parentOf(x)))
+			setColor(parentOf(x), Black)
+			setColor(rightOf(sibling), Black)
+			Self.rotateLeft(parentOf(x))
+			x = Self.root
+		} else {
+			sibling := leftOf(parentOf(x))
+			if colorOf(sibling) == Red {
+				setColor(sibling, Black)
+				setColor(parentOf(x), Red)
+				Self.rotateRight(parentOf(x))
+				sibling = leftOf(parentOf(x))
+			}
+			if colorOf(rightOf(sibling)) == Black && colorOf(leftOf(sibling)) == Black {
+				setColor(sibling, Red)
+				x = parentOf(x)
+				continue
+			}
+			if colorOf(leftOf(sibling)) == Black {
+				setColor(rightOf(sibling), Black)
+				setColor(sibling, Red)
+				Self.rotateLeft(sibling)
+				sibling = leftOf(parentOf(x))
+			}
+			setColor(sibling, colorOf(parentOf(x)))
+			setColor(parentOf(x), Black)
+			setColor(leftOf(sibling), Black)
+			Self.rotateRight(parentOf(x))
+			x = Self.root
+		}
+	}
+	setColor(x, Black)
+}
+
+// checkInvariants 检查红黑树是否满足以下性质，供测试使用：
+//  1. 根节点是黑色的；
+//  2. 红色节点的孩子都是黑色的；
+//  3. 从任一节点到其所有叶子节点的路径上，黑色节点的数量相同；
+//  4. 中序遍历的键严格递增，且父子指针一致；
+//  5. 节点的数量等于 size。
+func (Self *RBTree[K, V]) checkInvariants() error {
+	if colorOf(Self.root) != Black {
+		return errors.New("root is not black")
+	}
+	if Self.root != nil && Self.root.parent != nil {
+		return errors.New("root has a parent")
+	}
+	cnt, _, err := Self.checkSubtree(Self.root)
+	if err != nil {
+		return err
+	}
+	if cnt != Self.size {
+		return fmt.Errorf("size is %d, but there are %d nodes", Self.size, cnt)
+	}
+	return nil
+}
+
+// checkSubtree 检查以 n 为根的子树，返回子树的节点数量和黑高
+func (Self *RBTree[K, V]) checkSubtree(n *rbNode[K, V]) (int, int, error) {
+	if n == nil {
+		return 0, 1, nil
+	}
+	if n.color == Red && (colorOf(n.left) == Red || colorOf(n.right) == Red) {
+		return 0, 0, fmt.Errorf("red node %v has a red child", n.key)
+	}
+	if n.left != nil && (n.left.parent != n || Self.compare(n.left.key, n.key) >= 0) {
+		return 0, 0, fmt.Errorf("left child of node %v is invalid", n.key)
+	}
+	if n.right != nil && (n.right.parent != n || Self.compare(n.right.key, n.key) <= 0) {
+		return 0, 0, fmt.Errorf("right child of node %v is invalid", n.key)
+	}
+	leftCnt, leftHeight, err := Self.checkSubtree(n.left)
+	if err != nil {
+		return 0, 0, err
+	}
+	rightCnt, rightHeight, err := Self.checkSubtree(n.right)
+	if err != nil {
+		return 0, 0, err
+	}
+	if n.size != leftCnt+rightCnt+1 {
+		return 0, 0, fmt.Errorf("size of node %v is %d, want %d", n.key, n.size, leftCnt+rightCnt+1)
+	}
+	if leftHeight != rightHeight {
+		return 0, 0, fmt.Errorf("black height of node %v is unbalanced", n.key)
+	}
+	if n.color == Black {
+		leftHeight++
+	}
+	// 左右子树内部有序且左孩子小于 n、右孩子大于 n，还需保证子树中的最大/最小节点与 n 有序
+	if l := maximum(n.left); l != nil && Self.compare(l.key, n.key) >= 0 {
+		return 0, 0, fmt.Errorf("left subtree of node %v is out of order", n.key)
+	}
+	if r := minimum(n.right); r != nil && Self.compare(r.key, n.key) <= 0 {
+		return 0, 0, fmt.Errorf("right subtree of node %v is out of order", n.key)
 	}
+	return leftCnt + rightCnt + 1, leftHeight, nil
 }
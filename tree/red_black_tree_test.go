@@ -0,0 +1,456 @@
+// Package tree
+/**
+* @Project : GenericGo
+* @File    : red_black_tree_test.go
+* @IDE     : GoLand
+* @Author  : Tvux
+* @Date    : 2024/11/15 10:12
+**/
+
+package tree
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/HJH0924/GenericGo/errs"
+	"github.com/HJH0924/GenericGo/iterator"
+	"github.com/HJH0924/GenericGo/tuple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRBTree_Put(t *testing.T) {
+	tests := []struct {
+		name       string
+		keys       []int
+		wantKeys   []int
+		wantValues []int
+	}{
+		{
+			name:       "empty tree",
+			keys:       []int{},
+			wantKeys:   []int{},
+			wantValues: []int{},
+		},
+		{
+			name:       "ascending keys",
+			keys:       []int{1, 2, 3, 4, 5, 6, 7, 8},
+			wantKeys:   []int{1, 2, 3, 4, 5, 6, 7, 8},
+			wantValues: []int{10, 20, 30, 40, 50, 60, 70, 80},
+		},
+		{
+			name:       "descending keys",
+			keys:       []int{8, 7, 6, 5, 4, 3, 2, 1},
+			wantKeys:   []int{1, 2, 3, 4, 5, 6, 7, 8},
+			wantValues: []int{10, 20, 30, 40, 50, 60, 70, 80},
+		},
+		{
+			name:       "duplicate keys",
+			keys:       []int{5, 3, 5, 7, 3},
+			wantKeys:   []int{3, 5, 7},
+			wantValues: []int{30, 50, 70},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rbTree := NewRBTree[int, int](compareInt)
+			for _, key := range tt.keys {
+				rbTree.Put(key, key*10)
+				require.NoError(t, rbTree.checkInvariants())
+			}
+			assert.Equal(t, len(tt.wantKeys), rbTree.Size())
+			assert.Equal(t, tt.wantKeys, rbTree.Keys())
+			assert.Equal(t, tt.wantValues, rbTree.Values())
+		})
+	}
+}
+
+func TestRBTree_Get(t *testing.T) {
+	rbTree := NewRBTree[int, string](compareInt)
+	rbTree.Put(2, "b")
+	rbTree.Put(1, "a")
+	rbTree.Put(3, "c")
+
+	tests := []struct {
+		name    string
+		key     int
+		wantVal string
+		wantOk  bool
+	}{
+		{name: "root", key: 2, wantVal: "b", wantOk: true},
+		{name: "left child", key: 1, wantVal: "a", wantOk: true},
+		{name: "right child", key: 3, wantVal: "c", wantOk: true},
+		{name: "not exist", key: 4, wantVal: "", wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			val, ok := rbTree.Get(tt.key)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantVal, val)
+			assert.Equal(t, tt.wantOk, rbTree.Contains(tt.key))
+		})
+	}
+}
+
+func TestRBTree_Delete(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []int
+		delKey   int
+		wantVal  int
+		wantOk   bool
+		wantKeys []int
+	}{
+		{
+			name:     "delete from empty tree",
+			keys:     []int{},
+			delKey:   1,
+			wantKeys: []int{},
+		},
+		{
+			name:     "delete the only node",
+			keys:     []int{1},
+			delKey:   1,
+			wantVal:  10,
+			wantOk:   true,
+			wantKeys: []int{},
+		},
+		{
+			name:     "delete leaf",
+			keys:     []int{2, 1, 3},
+			delKey:   3,
+			wantVal:  30,
+			wantOk:   true,
+			wantKeys: []int{1, 2},
+		},
+		{
+			name:     "delete node with two children",
+			keys:     []int{4, 2, 6, 1, 3, 5, 7},
+			delKey:   4,
+			wantVal:  40,
+			wantOk:   true,
+			wantKeys: []int{1, 2, 3, 5, 6, 7},
+		},
+		{
+			name:     "delete not exist key",
+			keys:     []int{2, 1, 3},
+			delKey:   4,
+			wantKeys: []int{1, 2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rbTree := NewRBTree[int, int](compareInt)
+			for _, key := range tt.keys {
+				rbTree.Put(key, key*10)
+			}
+			val, ok := rbTree.Delete(tt.delKey)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantVal, val)
+			assert.Equal(t, tt.wantKeys, rbTree.Keys())
+			assert.Equal(t, len(tt.wantKeys), rbTree.Size())
+			assert.NoError(t, rbTree.checkInvariants())
+		})
+	}
+}
+
+func TestRBTree_MinMax(t *testing.T) {
+	rbTree := NewRBTree[int, int](compareInt)
+	_, _, ok := rbTree.Min()
+	assert.False(t, ok)
+	_, _, ok = rbTree.Max()
+	assert.False(t, ok)
+
+	for _, key := range []int{5, 9, 1, 7, 3} {
+		rbTree.Put(key, key*10)
+	}
+	key, val, ok := rbTree.Min()
+	assert.True(t, ok)
+	assert.Equal(t, 1, key)
+	assert.Equal(t, 10, val)
+
+	key, val, ok = rbTree.Max()
+	assert.True(t, ok)
+	assert.Equal(t, 9, key)
+	assert.Equal(t, 90, val)
+}
+
+func TestRBTree_Navigation(t *testing.T) {
+	rbTree := NewRBTree[int, int](compareInt)
+	for _, key := range []int{10, 20, 30, 40, 50} {
+		rbTree.Put(key, key*10)
+	}
+
+	type navFunc func(key int) (int, int, bool)
+	tests := []struct {
+		name    string
+		nav     navFunc
+		key     int
+		wantKey int
+		wantOk  bool
+	}{
+		{name: "floor exact", nav: rbTree.Floor, key: 30, wantKey: 30, wantOk: true},
+		{name: "floor between", nav: rbTree.Floor, key: 35, wantKey: 30, wantOk: true},
+		{name: "floor below min", nav: rbTree.Floor, key: 5, wantOk: false},
+		{name: "ceiling exact", nav: rbTree.Ceiling, key: 30, wantKey: 30, wantOk: true},
+		{name: "ceiling between", nav: rbTree.Ceiling, key: 35, wantKey: 40, wantOk: true},
+		{name: "ceiling above max", nav: rbTree.Ceiling, key: 55, wantOk: false},
+		{name: "lower exact", nav: rbTree.Lower, key: 30, wantKey: 20, wantOk: true},
+		{name: "lower min", nav: rbTree.Lower, key: 10, wantOk: false},
+		{name: "lower above max", nav: rbTree.Lower, key: 100, wantKey: 50, wantOk: true},
+		{name: "higher exact", nav: rbTree.Higher, key: 30, wantKey: 40, wantOk: true},
+		{name: "higher max", nav: rbTree.Higher, key: 50, wantOk: false},
+		{name: "higher below min", nav: rbTree.Higher, key: 0, wantKey: 10, wantOk: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, val, ok := tt.nav(tt.key)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantKey, key)
+			assert.Equal(t, tt.wantKey*10, val)
+		})
+	}
+}
+
+func TestRBTree_AscendDescend(t *testing.T) {
+	rbTree := NewRBTree[int, int](compareInt)
+	for _, key := range []int{3, 1, 4, 5, 2} {
+		rbTree.Put(key, key)
+	}
+
+	var res []int
+	rbTree.Ascend(func(key int, val int) bool {
+		res = append(res, key)
+		return true
+	})
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, res)
+
+	res = nil
+	rbTree.Descend(func(key int, val int) bool {
+		res = append(res, key)
+		return true
+	})
+	assert.Equal(t, []int{5, 4, 3, 2, 1}, res)
+
+	// 提前停止
+	res = nil
+	rbTree.Ascend(func(key int, val int) bool {
+		res = append(res, key)
+		return key < 3
+	})
+	assert.Equal(t, []int{1, 2, 3}, res)
+}
+
+func TestRBTree_Range(t *testing.T) {
+	rbTree := NewRBTree[int, int](compareInt)
+	for _, key := range []int{10, 20, 30, 40, 50} {
+		rbTree.Put(key, key)
+	}
+
+	tests := []struct {
+		name          string
+		from          int
+		fromInclusive bool
+		to            int
+		toInclusive   bool
+		stopAt        int
+		wantAsc       []int
+		wantDesc      []int
+	}{
+		{
+			name: "closed", from: 20, fromInclusive: true, to: 40, toInclusive: true,
+			wantAsc: []int{20, 30, 40}, wantDesc: []int{},
+		},
+		{
+			name: "open", from: 20, to: 40,
+			wantAsc: []int{30}, wantDesc: []int{},
+		},
+		{
+			name: "half open", from: 20, fromInclusive: true, to: 40,
+			wantAsc: []int{20, 30}, wantDesc: []int{},
+		},
+		{
+			name: "bounds not in tree", from: 15, to: 45,
+			wantAsc: []int{20, 30, 40}, wantDesc: []int{},
+		},
+		{
+			name: "reversed bounds", from: 40, fromInclusive: true, to: 20, toInclusive: true,
+			wantAsc: []int{}, wantDesc: []int{40, 30, 20},
+		},
+		{
+			name: "stop early", from: 0, to: 100, stopAt: 30,
+			wantAsc: []int{10, 20, 30}, wantDesc: []int{},
+		},
+		{
+			name: "empty range", from: 21, to: 29,
+			wantAsc: []int{}, wantDesc: []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			visit := func(res *[]int) func(key int, val int) bool {
+				return func(key int, val int) bool {
+					*res = append(*res, key)
+					return key != tt.stopAt
+				}
+			}
+			asc := make([]int, 0)
+			rbTree.Range(tt.from, tt.fromInclusive, tt.to, tt.toInclusive, visit(&asc))
+			assert.Equal(t, tt.wantAsc, asc)
+
+			desc := make([]int, 0)
+			rbTree.DescendRange(tt.from, tt.fromInclusive, tt.to, tt.toInclusive, visit(&desc))
+			assert.Equal(t, tt.wantDesc, desc)
+		})
+	}
+}
+
+func TestRBTree_RankSelect(t *testing.T) {
+	rbTree := NewRBTree[int, int](compareInt)
+	for _, key := range []int{50, 10, 40, 20, 30} {
+		rbTree.Put(key, key*10)
+	}
+
+	rankTests := []struct {
+		name     string
+		key      int
+		wantRank int
+	}{
+		{name: "min", key: 10, wantRank: 0},
+		{name: "middle", key: 30, wantRank: 2},
+		{name: "max", key: 50, wantRank: 4},
+		{name: "not exist", key: 35, wantRank: 3},
+		{name: "below min", key: 0, wantRank: 0},
+		{name: "above max", key: 60, wantRank: 5},
+	}
+	for _, tt := range rankTests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantRank, rbTree.Rank(tt.key))
+		})
+	}
+
+	selectTests := []struct {
+		name    string
+		k       int
+		wantKey int
+		wantErr error
+	}{
+		{name: "first", k: 0, wantKey: 10},
+		{name: "middle", k: 2, wantKey: 30},
+		{name: "last", k: 4, wantKey: 50},
+		{name: "negative", k: -1, wantErr: errs.NewErrIndexOutOfRange(5, -1)},
+		{name: "out of range", k: 5, wantErr: errs.NewErrIndexOutOfRange(5, 5)},
+	}
+	for _, tt := range selectTests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, val, err := rbTree.Select(tt.k)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantKey, key)
+			assert.Equal(t, tt.wantKey*10, val)
+		})
+	}
+}
+
+// TestRBTree_Random 随机插入和删除，并与 map 的结果对比，同时校验红黑树的性质
+func TestRBTree_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	rbTree := NewRBTree[int, int](compareInt)
+	m := make(map[int]int)
+
+	for i := 0; i < 20000; i++ {
+		key := r.Intn(1000)
+		if r.Intn(3) == 0 {
+			val, ok := rbTree.Delete(key)
+			wantVal, wantOk := m[key]
+			require.Equal(t, wantOk, ok)
+			require.Equal(t, wantVal, val)
+			delete(m, key)
+		} else {
+			rbTree.Put(key, i)
+			m[key] = i
+		}
+		if i%100 == 0 {
+			require.NoError(t, rbTree.checkInvariants())
+		}
+	}
+	require.NoError(t, rbTree.checkInvariants())
+
+	// 顺序统计与有序的键一致
+	keys := rbTree.Keys()
+	for i, key := range keys {
+		require.Equal(t, i, rbTree.Rank(key))
+		selected, _, err := rbTree.Select(i)
+		require.NoError(t, err)
+		require.Equal(t, key, selected)
+	}
+
+	wantKeys := make([]int, 0, len(m))
+	for key := range m {
+		wantKeys = append(wantKeys, key)
+	}
+	sort.Ints(wantKeys)
+	assert.Equal(t, wantKeys, rbTree.Keys())
+	assert.Equal(t, len(m), rbTree.Size())
+}
+
+// TestRBTree_DeleteRandomOrder 插入一组键之后按照随机顺序逐个删除，每次删除之后都校验红黑树的性质
+func TestRBTree_DeleteRandomOrder(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 10, 100, 1000} {
+		rbTree := NewRBTree[int, int](compareInt)
+		for _, key := range r.Perm(n) {
+			rbTree.Put(key, key*10)
+		}
+		require.NoError(t, rbTree.checkInvariants())
+
+		for i, key := range r.Perm(n) {
+			val, ok := rbTree.Delete(key)
+			require.True(t, ok)
+			require.Equal(t, key*10, val)
+			require.False(t, rbTree.Contains(key))
+			require.Equal(t, n-i-1, rbTree.Size())
+			require.NoError(t, rbTree.checkInvariants())
+		}
+		assert.Equal(t, []int{}, rbTree.Keys())
+	}
+}
+
+func compareInt(left int, right int) int {
+	if left < right {
+		return -1
+	} else if left > right {
+		return 1
+	}
+	return 0
+}
+
+func TestRBTree_Iterator(t *testing.T) {
+	tree := NewRBTree[int, string](compareInt)
+	assert.Equal(t, []tuple.Pair[int, string]{}, iterator.Collect(tree.Iterator()))
+	assert.Equal(t, []tuple.Pair[int, string]{}, iterator.Collect(tree.ReverseIterator()))
+
+	for _, key := range []int{5, 2, 8, 1, 9} {
+		tree.Put(key, fmt.Sprint(key))
+	}
+	keys := iterator.Collect(iterator.Map(tree.Iterator(), func(p tuple.Pair[int, string]) int {
+		return p.Key
+	}))
+	assert.Equal(t, []int{1, 2, 5, 8, 9}, keys)
+
+	reversed := iterator.Collect(tree.ReverseIterator())
+	assert.Equal(t, []tuple.Pair[int, string]{
+		tuple.NewPair(9, "9"),
+		tuple.NewPair(8, "8"),
+		tuple.NewPair(5, "5"),
+		tuple.NewPair(2, "2"),
+		tuple.NewPair(1, "1"),
+	}, reversed)
+}